@@ -5,9 +5,28 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/codeafix/apicovchk/coverage"
+	"github.com/codeafix/apicovchk/report"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "proxy" {
+		success, conf, outfilename, po := parseProxyCommandLineOptions(os.Args)
+		if !success {
+			printUsage()
+			return
+		}
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		err := proxy(conf, outfilename, po, stop)
+		if err != nil {
+			fmt.Printf("Error processing coverage check: %s\n\n", err.Error())
+		}
+		return
+	}
 	success, conf, outfilename := parseCommandLineOptions(os.Args)
 	if success {
 		err := covcheck(conf, outfilename)
@@ -19,21 +38,48 @@ func main() {
 	}
 }
 
-func covcheck(conf Config, outfilename string) error {
-	cc := NewCovChecker()
-	err := cc.CheckCoverage(conf)
+func covcheck(conf coverage.Config, outfilename string) error {
+	cc, err := coverage.New(conf).AddLogs().Result()
 	if err != nil {
 		return err
 	}
-	cc.NavigatePathMap()
-	hw := NewHTMLWriter(cc)
+	return writeReport(conf, cc, outfilename)
+}
+
+func writeReport(conf coverage.Config, cc *coverage.CovCheckerInfo, outfilename string) error {
+	fmt.Printf("%d of %d operations called\n", cc.OperationsCovered, cc.Operations)
+	if len(cc.Violations) > 0 {
+		fmt.Printf("%d operations do not satisfy the coverage policy, see '%s' for details\n", len(cc.Violations), outfilename)
+	}
+	if len(cc.ContractViolations) > 0 {
+		fmt.Printf("%d contract violations found in the logged calls, see '%s' for details\n", len(cc.ContractViolations), outfilename)
+	}
+	if len(cc.PathMap.Findings) > 0 {
+		fmt.Printf("%d spec quality findings in the Swagger files, see '%s' for details\n", len(cc.PathMap.Findings), outfilename)
+	}
+	if len(cc.DeprecatedCalls) > 0 {
+		fmt.Printf("%d deprecated operations are still being called, see '%s' for details\n", len(cc.DeprecatedCalls), outfilename)
+	}
+	if len(cc.CriticalGaps) > 0 {
+		fmt.Printf("%d critical operations are not fully covered, see '%s' for details\n", len(cc.CriticalGaps), outfilename)
+	}
+	for _, owner := range cc.OwnersBelowThreshold() {
+		fmt.Printf("Coverage of %3.2f%% for owner '%s' is below its threshold of %3.2f%%\n", owner.Coverage*100, owner.Name, owner.Threshold*100)
+	}
+	if conf.DraftFragments != "" {
+		err := cc.PathMap.WriteDraftFragments(conf.DraftFragments, cc.IsIgnored)
+		if err != nil {
+			return err
+		}
+	}
+	hw := report.NewHTMLWriter(cc)
 	return hw.Write(outfilename)
 }
 
-func parseCommandLineOptions(args []string) (success bool, conf Config, outfilename string) {
+func parseCommandLineOptions(args []string) (success bool, conf coverage.Config, outfilename string) {
 	success = true
 	outfilename = "coverage.html"
-	conf = Config{}
+	conf = coverage.Config{}
 	if len(args) <= 1 || args[1] == "-help" {
 		return false, conf, outfilename
 	}
@@ -92,6 +138,7 @@ transaction files over an API as it is documented in the Swagger files.
 
 Usage:
       apicovchk -opt <optionsFile> -out <covFileName>
+      apicovchk proxy -opt <optionsFile> -upstream <upstreamURL> -listen <address> -log <logFileName> -out <covFileName>
       apicovchk -help
 
 Options:
@@ -118,9 +165,37 @@ Options:
 			"routePath": "open-api-spec",
 			"swagger": "https://raw.githubusercontent.com/OAI/OpenAPI-Specification/master/examples/v2.0/json/api-with-examples.json"
 		  }
-		]
+		],
+		"policy": {
+			"requiredResponseClasses": ["2xx", "4xx"]
+		},
+		"weights": {
+			"requiredParameter": 3,
+			"optionalParameter": 1,
+			"successResponse": 3,
+			"clientErrorResponse": 2,
+			"otherResponse": 0.5,
+			"security": 2,
+			"called": 1
+		}
 	  }
 	  NOTE: Paths for swagger files and log files can be local file URLs or web URLs.
+			The keys of the options file are:
+			* "transactionLogFiles": the log files, each with a "logURL" and a "logType".
+			* "services": each "swagger" with its "routePath" e.g. "api/v2/petstore", and
+			  optional "host", "useSwaggerHost", "useBasePath" and "name".
+			* "policy": the response classes (2xx, 4xx, 5xx) every operation must cover.
+			* "weights": the weight of each category of coverage point (default 1).
+			* "rewrites": regular expressions ("match") replaced ("replace") in logged paths.
+			* "normalise": "trailingSlash", "duplicateSlashes", "percentDecode", "caseInsensitive".
+			* "idPatterns": "uuid", "integer", "hex" or regexps for IDs in undocumented paths.
+			* "ignore": rules ("service", "path" glob, "method", "response") to leave out items.
+			* "includeDeprecated": true to count deprecated operations in the coverage.
+			* "ownership": owner "rules", an ownership "file", and coverage "thresholds".
+			* "criticality": the factor of each criticality level in the risk-weighted score.
+			* "draftFragments": a file to write draft Swagger for the undocumented items to.
+			The "x-coverage-ignore", "x-owner" and "x-criticality" extensions of an operation
+			ignore it, set its owner, and set its criticality (low, medium, high or critical).
 			There are two types of log file format supported:
 			* Sumo: A comma separated file in the form:
 					API,Response Code
@@ -131,9 +206,32 @@ Options:
 					duration(ms)	start-time	end-time	method	url	body	response
 					663	18:55.0	18:55.6	POST	https://127.0.0.1:8081/petstore/user	undefined	200
 					749	18:55.6	18:56.4	GET	https://127.0.0.1:8081/petstore/user/login	undefined	400
+				  Form-encoded or multipart (line breaks escaped as \r\n) bodies are checked
+				  against the formData parameters of the operation.
+				  An optional eighth column may hold the request headers as "Name: value"
+				  pairs separated by "|". These are used to check that secured operations
+				  have been called both with credentials and without (rejected with 401/403).
+				  An optional ninth column may hold the JSON response body.
+				  Logged calls to documented operations are validated against the Swagger
+				  description: parameter types, required parameters, and JSON request and
+				  response bodies against their schemas. Violations are listed in the report.
 -out <covFileName>
       An HTML file containing the computed coverage report. If this option is not specified the utility
       create a file called "coverage.html" in the current directory.
 -help
-    Prints this message.`)
+    Prints this message.
+
+Proxy command:
+      The proxy command listens on a local address and forwards every request to the
+      upstream URL, recording each transaction until the proxy is stopped (e.g. with
+      Ctrl+C). The coverage report of the recorded transactions is then written, in
+      the same way as for the log files in the options file. Point a test suite at the
+      proxy instead of the service to measure the coverage of the tests.
+-upstream <upstreamURL>
+      The URL of the service the requests are forwarded to e.g. "http://localhost:9000".
+-listen <address>
+      The address the proxy listens on. The default is "localhost:8080".
+-log <logFileName>
+      A file the recorded transactions are written to in the Transaction log format.
+      This option is optional.`)
 }
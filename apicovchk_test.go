@@ -1,9 +1,10 @@
 package main
 
 import (
-	"os"
-	"strings"
 	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
 )
 
 func TestParseCommandOptionsFailsWhenOnlyHelp(t *testing.T) {
@@ -19,9 +20,9 @@ func TestParseCommandOptionsReadOptionsFile(t *testing.T) {
 	AreEqual(t, "coverage.html", outfilename, "outfilename not set to default")
 	AreEqual(t, 2, len(conf.TransactionLogs), "Wrong number of transaction logs")
 	AreEqual(t, "log1.txt", conf.TransactionLogs[0].LogURL, "Wrong transaction log filename")
-	AreEqual(t, LogType(Sumo), conf.TransactionLogs[0].LogType, "Wrong transaction log type")
+	AreEqual(t, logs.LogType(logs.Sumo), conf.TransactionLogs[0].LogType, "Wrong transaction log type")
 	AreEqual(t, "log2.txt", conf.TransactionLogs[1].LogURL, "Wrong transaction log filename")
-	AreEqual(t, LogType(Transaction), conf.TransactionLogs[1].LogType, "Wrong transaction log type")
+	AreEqual(t, logs.LogType(logs.Transaction), conf.TransactionLogs[1].LogType, "Wrong transaction log type")
 	AreEqual(t, 2, len(conf.Services), "Wrong number of services")
 	AreEqual(t, "petstore", conf.Services[0].RoutePath, "Wrong service route name")
 	AreEqual(t, "open-api-spec", conf.Services[1].RoutePath, "Wrong service route name")
@@ -70,50 +71,3 @@ func TestParseCommandOptionsFailsWithUnrecognisedOption(t *testing.T) {
 	success, _, _ := parseCommandLineOptions(args)
 	IsFalse(t, success, "Expected parse to fail")
 }
-
-func IsFalse(t *testing.T, condition bool, msg string) {
-	if condition {
-		t.Error(msg)
-	}
-}
-
-func IsTrue(t *testing.T, condition bool, msg string) {
-	if !condition {
-		t.Error(msg)
-	}
-}
-
-func AreEqual(t *testing.T, expected interface{}, actual interface{}, msg string) {
-	if expected != actual {
-		t.Errorf("%s Expected = %v,%T Actual = %v,%T", msg, expected, expected, actual, actual)
-	}
-}
-
-func NotEqual(t *testing.T, expected interface{}, actual interface{}, msg string) {
-	if expected == actual {
-		t.Errorf("%s Expected = %v Actual = %v", msg, expected, actual)
-	}
-}
-
-func AssertSuccess(t *testing.T, err error) {
-	if err != nil {
-		t.Errorf(err.Error())
-	}
-}
-
-func CheckGold(t *testing.T, goldfile, json string) {
-	gold, err := os.ReadFile(goldfile)
-	AssertSuccess(t, err)
-
-	goldLF := strings.ReplaceAll(string(gold), "\r", "")
-	jsonLF := strings.ReplaceAll(json, "\r", "")
-
-	if string(goldLF) != jsonLF {
-		f, err := os.Create("temp/check_" + goldfile)
-		AssertSuccess(t, err)
-		defer f.Close()
-		_, err = f.Write([]byte(json))
-		AssertSuccess(t, err)
-		t.Errorf("The generated file is different from the gold file %s", goldfile)
-	}
-}
@@ -0,0 +1,24 @@
+package coverage
+
+import (
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//Config contains the list of transaction log files to read, and an entry for each
+//service that defines the reverse proxy path name for the service, and the
+//swagger.json file to use.
+type Config struct {
+	TransactionLogs   []logs.LogEntry        `json:"transactionLogFiles"`
+	Services          []pathmap.ServiceEntry `json:"services"`
+	Policy            Policy                 `json:"policy"`
+	Weights           Weights                `json:"weights"`
+	Rewrites          []logs.RewriteRule     `json:"rewrites"`
+	Normalise         logs.Normalisation     `json:"normalise"`
+	IDPatterns        []string               `json:"idPatterns"`
+	Ignore            []IgnoreRule           `json:"ignore"`
+	IncludeDeprecated bool                   `json:"includeDeprecated"`
+	Ownership         Ownership              `json:"ownership"`
+	Criticality       Criticality            `json:"criticality"`
+	DraftFragments    string                 `json:"draftFragments"`
+}
@@ -0,0 +1,36 @@
+package coverage
+
+import (
+	"sort"
+
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//ContractViolation records the number of logged calls to an operation that broke
+//the operation's contract in the same way
+type ContractViolation struct {
+	Service string
+	Path    string
+	Method  string
+	Message string
+	Count   int
+}
+
+//AddContractViolations adds the contract violations recorded against the verb
+//to the list of violations in the report
+func (cc *CovCheckerInfo) AddContractViolations(service, path string, verb *pathmap.Verb) {
+	msgs := []string{}
+	for msg := range verb.Violations {
+		msgs = append(msgs, msg)
+	}
+	sort.Strings(msgs)
+	for _, msg := range msgs {
+		cc.ContractViolations = append(cc.ContractViolations, ContractViolation{
+			Service: service,
+			Path:    path,
+			Method:  verb.Name,
+			Message: msg,
+			Count:   verb.Violations[msg],
+		})
+	}
+}
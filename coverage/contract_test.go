@@ -0,0 +1,23 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func TestNavigatePathMapListsContractViolations(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{"/pet":{"get":{
+		"parameters":[{"name":"status","in":"query","type":"string","required":true}],
+		"responses":{"200":{"description":"ok"}}}}}}`
+	cc := NewCovChecker()
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	for i := 0; i < 2; i++ {
+		cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "200"})
+	}
+	cc.NavigatePathMap()
+	AreEqual(t, 1, len(cc.ContractViolations), "Wrong number of contract violations")
+	AreEqual(t, ContractViolation{Service: "petstore", Path: "/pet", Method: "GET", Message: "missing required query parameter 'status'", Count: 2},
+		cc.ContractViolations[0], "Wrong contract violation")
+}
@@ -0,0 +1,429 @@
+//Package coverage calculates the coverage of an API from logged requests. A
+//Coverage Checker is created from a Config, fed log entries, and returns the
+//coverage stats for each service, endpoint and operation
+package coverage
+
+import (
+	"fmt"
+
+	"github.com/codeafix/apicovchk/filereader"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//CovCheckerInfo holds a reference to the PathMap that contains all of the paths
+//from the loaded Swagger files and will be used to track the Paths that have
+//been used in a request from the transaction log files
+type CovCheckerInfo struct {
+	PathMap            *pathmap.PathMap
+	FileReader         filereader.FileReader
+	ServiceStats       []*ServiceStat
+	Policy             Policy
+	Violations         []PolicyViolation
+	ContractViolations []ContractViolation
+	Weights            Weights
+	Ignore             []IgnoreRule
+	DeprecatedCalls    []DeprecatedCall
+	TagStats           []*TagStat
+	Ownership          Ownership
+	OwnerStats         []*OwnerStat
+	Criticality        Criticality
+	CriticalGaps       []CriticalGap
+	HasCriticality     bool
+	IncludeDeprecated  bool
+	Coverage           float64
+	Undocumented       float64
+	TotalPoint         float64
+	Weighted           float64
+	WeightedTot        float64
+	Risk               float64
+	RiskTot            float64
+	Operations         int
+	OperationsCovered  int
+	config             Config
+	rewriter           *logs.Rewriter
+	err                error
+}
+
+//ServiceStat collects the aggregate coverage for an entire service
+type ServiceStat struct {
+	Name              string
+	Endpoints         []EndpointStat
+	Coverage          float64
+	Undocumented      float64
+	TotalPoint        float64
+	Weighted          float64
+	WeightedTot       float64
+	Risk              float64
+	RiskTot           float64
+	Operations        int
+	OperationsCovered int
+}
+
+//EndpointStat collects the coverage stats for a specific endpoint
+type EndpointStat struct {
+	Path         string
+	Verbs        []VerbStat
+	Coverage     float64
+	Undocumented float64
+	Weighted     float64
+	Classes      map[string]*ClassStat
+}
+
+//VerbStat collects the coverage counts for a specific verb on
+//an endpoint
+type VerbStat struct {
+	Service      string
+	Path         string
+	Method       string
+	OperationID  string
+	Summary      string
+	Tags         []string
+	Owner        string
+	Criticality  string
+	Called       bool
+	Total        int
+	Covered      int
+	Undocumented int
+	WeightedTot  float64
+	WeightedCov  float64
+	Responses    map[string]*pathmap.Response
+	Parameters   map[string]*pathmap.QueryParameter
+	FormParams   map[string]*pathmap.QueryParameter
+	Security     *pathmap.Security
+	Classes      map[string]*ClassStat
+}
+
+//NewCovChecker returns a new instance of the Coverage Checker
+func NewCovChecker() *CovCheckerInfo {
+	return &CovCheckerInfo{
+		PathMap: pathmap.NewPathMap(),
+	}
+}
+
+//New returns a Coverage Checker for the passed configuration, with the Swagger
+//files of the configured services read into its PathMap. Logged requests are
+//added with AddEntries or AddLogs, and Result calculates the coverage e.g.
+//
+//	cc, err := coverage.New(config).AddLogs().Result()
+//
+//An error reading the configuration, or any of the logs, is returned by Result
+func New(config Config) *CovCheckerInfo {
+	cc := NewCovChecker()
+	cc.err = cc.Configure(config)
+	return cc
+}
+
+//Configure sets the rules used to calculate the coverage from the passed
+//configuration, and reads the Swagger files of the configured services
+func (cc *CovCheckerInfo) Configure(config Config) error {
+	cc.config = config
+	cc.Policy = config.Policy
+	cc.Weights = config.Weights
+	cc.Ignore = config.Ignore
+	cc.IncludeDeprecated = config.IncludeDeprecated
+	cc.Ownership = config.Ownership
+	cc.Criticality = config.Criticality
+	if config.Ownership.File != "" {
+		rules, err := ReadOwnershipFile(config.Ownership.File)
+		if err != nil {
+			return err
+		}
+		cc.Ownership.Rules = append(cc.Ownership.Rules, rules...)
+	}
+	rw, err := logs.NewRewriter(config.Rewrites)
+	if err != nil {
+		return err
+	}
+	rw.Normalisation = config.Normalise
+	cc.rewriter = rw
+	cc.PathMap.CaseInsensitive = config.Normalise.CaseInsensitive
+	idps, err := pathmap.CompileIDPatterns(config.IDPatterns)
+	if err != nil {
+		return err
+	}
+	cc.PathMap.IDPatterns = idps
+	return cc.PathMap.ReadSwagger(config.Services)
+}
+
+//AddEntries applies the configured rewrite rules to each of the passed log
+//entries and checks them against the PathMap
+func (cc *CovCheckerInfo) AddEntries(entries ...logs.RequestLogEntry) *CovCheckerInfo {
+	if cc.err != nil {
+		return cc
+	}
+	for _, entry := range entries {
+		//Skip entries that are rewritten to an empty path
+		if cc.rewriter == nil || cc.rewriter.Rewrite(&entry) == nil {
+			cc.PathMap.CheckRequestLogEntry(entry)
+		}
+	}
+	return cc
+}
+
+//AddLogs reads each of the configured transaction log files and adds their
+//entries to the coverage
+func (cc *CovCheckerInfo) AddLogs() *CovCheckerInfo {
+	if cc.err != nil {
+		return cc
+	}
+	lrr := logs.NewLogReaderRepo()
+	for _, logFile := range cc.config.TransactionLogs {
+		lr := lrr.GetLogReader(logFile.LogType)
+		lr.SetNormalisation(cc.config.Normalise)
+		err := lr.SetLogURL(logFile.LogURL)
+		if err != nil {
+			cc.err = err
+			return cc
+		}
+		lel, err := lr.GetLogEntries()
+		if err != nil {
+			cc.err = err
+			return cc
+		}
+		cc.AddEntries(lel...)
+	}
+	return cc
+}
+
+//Result calculates the coverage stats from the entries added to the Coverage
+//Checker and returns the Coverage Checker, or the first error found while
+//configuring it or reading the logs
+func (cc *CovCheckerInfo) Result() (*CovCheckerInfo, error) {
+	if cc.err != nil {
+		return cc, cc.err
+	}
+	cc.NavigatePathMap()
+	return cc, nil
+}
+
+//CheckCoverage first reads all of the swagger files specified in the passed
+//configuration. Then it loads all of the transaction log files specified and
+//measures how much of the API in the swagger descriptions has been accessed
+//by the transactions in the log files. The configured rewrite rules are applied
+//to each transaction before it is checked
+func (cc *CovCheckerInfo) CheckCoverage(config Config) error {
+	cc.err = cc.Configure(config)
+	return cc.AddLogs().err
+}
+
+//NavigatePathMap navigates over the path map and calculates the coverage
+//stats for each verb on the path, overall stats for the path, and for
+//the services. A risk-weighted score is calculated alongside the coverage using
+//the criticality of each operation
+func (cc *CovCheckerInfo) NavigatePathMap() {
+	cc.ServiceStats = []*ServiceStat{}
+	cc.Violations = []PolicyViolation{}
+	cc.ContractViolations = []ContractViolation{}
+	cc.DeprecatedCalls = []DeprecatedCall{}
+	cc.TagStats = []*TagStat{}
+	cc.OwnerStats = []*OwnerStat{}
+	cc.CriticalGaps = []CriticalGap{}
+	cc.Coverage, cc.Undocumented, cc.TotalPoint = 0, 0, 0
+	cc.Weighted, cc.WeightedTot = 0, 0
+	cc.Risk, cc.RiskTot, cc.HasCriticality = 0, 0, false
+	cc.Operations, cc.OperationsCovered = 0, 0
+	for _, sn := range cc.PathMap.SortedServiceNames() {
+		srv := cc.PathMap.Services[sn]
+		if cc.IsIgnored(sn, "", "", "") {
+			continue
+		}
+		ss := &ServiceStat{
+			Name:      sn,
+			Endpoints: []EndpointStat{},
+		}
+		cc.ServiceStats = append(cc.ServiceStats, ss)
+		cc.NavigatePathItem(ss, srv, "")
+		ss.Coverage = Ratio(ss.Coverage, ss.TotalPoint)
+		ss.Undocumented = Ratio(ss.Undocumented, ss.TotalPoint)
+		ss.Weighted = Ratio(ss.Weighted, ss.WeightedTot)
+		ss.Risk = Ratio(ss.Risk, ss.RiskTot)
+	}
+	cc.Coverage = Ratio(cc.Coverage, cc.TotalPoint)
+	cc.Undocumented = Ratio(cc.Undocumented, cc.TotalPoint)
+	cc.Weighted = Ratio(cc.Weighted, cc.WeightedTot)
+	cc.Risk = Ratio(cc.Risk, cc.RiskTot)
+	cc.SortCriticalGaps()
+	cc.CalculateTagStats()
+	cc.CalculateOwnerStats()
+}
+
+//NavigatePathItem iterates over path items descending the path hierarchy
+//and uses another function to generate the stats for each verb it finds
+func (cc *CovCheckerInfo) NavigatePathItem(ss *ServiceStat, pi *pathmap.PathItem, path string) {
+	for _, child := range pathmap.SortedPathItems(pi.PathItems) {
+		cpath := fmt.Sprintf("%s/%s", path, child.MapKey())
+		var tot, cov, und, wtot, wcov float64
+		if child.Verbs != nil && !cc.IsIgnored(ss.Name, cpath, "", "") {
+			es := EndpointStat{
+				Path:    cpath,
+				Verbs:   []VerbStat{},
+				Classes: map[string]*ClassStat{},
+			}
+			for _, verb := range pathmap.SortedVerbs(child.Verbs) {
+				if verb.Ignored || cc.IsIgnored(ss.Name, cpath, verb.Name, "") {
+					continue
+				}
+				if cc.CheckDeprecated(ss.Name, cpath, verb) {
+					continue
+				}
+				for _, resp := range verb.Responses {
+					resp.Ignored = cc.IsIgnored(ss.Name, cpath, verb.Name, resp.Response)
+				}
+				vs := cc.CalculateVerbStats(verb)
+				vs.Service = ss.Name
+				vs.Path = cpath
+				vs.Owner = cc.OwnerOf(ss.Name, cpath, verb)
+				cc.AddTagStats(vs)
+				cc.AddOwnerStats(vs)
+				cc.AddRiskStats(ss, vs)
+				if verb.Documented {
+					ss.Operations++
+					cc.Operations++
+					if vs.Called {
+						ss.OperationsCovered++
+						cc.OperationsCovered++
+					}
+				}
+				cc.CheckPolicy(ss.Name, cpath, verb, vs)
+				cc.AddContractViolations(ss.Name, cpath, verb)
+				for class, cs := range vs.Classes {
+					ecs, exists := es.Classes[class]
+					if !exists {
+						ecs = &ClassStat{}
+						es.Classes[class] = ecs
+					}
+					ecs.Total += cs.Total
+					ecs.Covered += cs.Covered
+				}
+				tot = tot + float64(vs.Total)
+				cov = cov + float64(vs.Covered)
+				und = und + float64(vs.Undocumented)
+				wtot = wtot + vs.WeightedTot
+				wcov = wcov + vs.WeightedCov
+				es.Verbs = append(es.Verbs, vs)
+			}
+			if len(es.Verbs) == 0 {
+				cc.NavigatePathItem(ss, child, cpath)
+				continue
+			}
+			es.Coverage = Ratio(cov, tot)
+			es.Undocumented = Ratio(und, tot)
+			es.Weighted = Ratio(wcov, wtot)
+			ss.Endpoints = append(ss.Endpoints, es)
+			ss.Coverage += cov
+			ss.Undocumented += und
+			ss.TotalPoint += tot
+			ss.Weighted += wcov
+			ss.WeightedTot += wtot
+			cc.Coverage += cov
+			cc.Undocumented += und
+			cc.TotalPoint += tot
+			cc.Weighted += wcov
+			cc.WeightedTot += wtot
+		}
+		cc.NavigatePathItem(ss, child, cpath)
+	}
+}
+
+//CalculateVerbStats generates a coverage statistic for the verb by dividing
+//the logged response codes, query and formData parameters against the documented
+//response codes, query and formData parameters. Every operation has a point for
+//having been called at all, and secured operations have two further points, one
+//for an authorized call and one for a call rejected as unauthorized.
+//A weighted statistic is also calculated using the configured weight of each point
+func (cc *CovCheckerInfo) CalculateVerbStats(verb *pathmap.Verb) VerbStat {
+	vs := VerbStat{
+		Method:      verb.Name,
+		OperationID: verb.OperationID,
+		Summary:     verb.Summary,
+		Tags:        verb.Tags,
+		Criticality: verb.Criticality,
+		Called:      verb.Calls() > 0,
+		Responses:   map[string]*pathmap.Response{},
+		Parameters:  verb.QueryParameters,
+		FormParams:  verb.FormParameters,
+		Security:    verb.Security,
+		Classes: map[string]*ClassStat{
+			pathmap.SuccessClass:     {},
+			pathmap.ClientErrorClass: {},
+			pathmap.ServerErrorClass: {},
+		},
+	}
+	vs.AddPoint(vs.Called, verb.Documented, cc.Weights.Weight(CalledWeight))
+	for _, response := range pathmap.SortedResponses(verb.Responses) {
+		if response.Ignored {
+			continue
+		}
+		vs.Responses[response.Response] = response
+		vs.AddPoint(response.Covered > 0, response.Documented, cc.Weights.ResponseWeight(response))
+		vs.AddClassStats(response)
+	}
+	for _, param := range pathmap.SortedParameters(verb.QueryParameters) {
+		vs.AddPoint(param.Covered > 0, param.Documented, cc.Weights.ParameterWeight(param))
+	}
+	for _, param := range pathmap.SortedParameters(verb.FormParameters) {
+		vs.AddPoint(param.Covered > 0, param.Documented, cc.Weights.ParameterWeight(param))
+	}
+	if verb.Security != nil {
+		vs.AddPoint(verb.Security.Authorized > 0, true, cc.Weights.Weight(SecurityWeight))
+		vs.AddPoint(verb.Security.Unauthorized > 0, true, cc.Weights.Weight(SecurityWeight))
+	}
+	return vs
+}
+
+//Ratio returns n divided by d, or 0 if d is 0. A set of coverage points that is
+//empty, e.g. a service where everything is ignored, or points that all have a
+//weight of 0, has no evidence of being covered so its coverage is 0, and nothing
+//in it is undocumented so its undocumented ratio is also 0
+func Ratio(n, d float64) float64 {
+	if d == 0 {
+		return 0
+	}
+	return n / d
+}
+
+//CoverageRatio returns the fraction of the verb's points that are covered
+func (vs VerbStat) CoverageRatio() float64 {
+	return Ratio(float64(vs.Covered), float64(vs.Total))
+}
+
+//UndocumentedRatio returns the fraction of the verb's points that are undocumented
+func (vs VerbStat) UndocumentedRatio() float64 {
+	return Ratio(float64(vs.Undocumented), float64(vs.Total))
+}
+
+//AddPoint adds a single coverage point with the passed weight to the verb stats
+func (vs *VerbStat) AddPoint(covered, documented bool, weight float64) {
+	vs.Total = vs.Total + 1
+	vs.WeightedTot = vs.WeightedTot + weight
+	if !documented {
+		vs.Undocumented = vs.Undocumented + 1
+	}
+	if covered {
+		vs.Covered = vs.Covered + 1
+		vs.WeightedCov = vs.WeightedCov + weight
+	}
+}
+
+//AddClassStats adds the response to the stats for its class of response code.
+//A default response is added to the class of each of the codes it matched
+func (vs *VerbStat) AddClassStats(response *pathmap.Response) {
+	classes := map[string]bool{pathmap.ResponseClass(response.Response): true}
+	if response.Response == pathmap.DefaultResponseKey {
+		classes = map[string]bool{}
+		for _, code := range response.Matched {
+			classes[pathmap.ResponseClass(code)] = true
+		}
+	}
+	for class := range classes {
+		cs := vs.Classes[class]
+		if cs == nil {
+			continue
+		}
+		cs.Total = cs.Total + 1
+		if response.Covered > 0 {
+			cs.Covered = cs.Covered + 1
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package coverage
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func TestCheckCoverage(t *testing.T) {
+	c := Config{}
+	PetstoreConfig(t, &c)
+	cc := NewCovChecker()
+	err := cc.CheckCoverage(c)
+	AssertSuccess(t, err)
+
+	CheckGold(t, "PathMapFromCovCheckTest.json", cc.PathMap.JSON())
+}
+
+func TestNewAddLogsResult(t *testing.T) {
+	c := Config{}
+	PetstoreConfig(t, &c)
+	cc, err := New(c).AddLogs().Result()
+	AssertSuccess(t, err)
+
+	CheckGold(t, "PathMapFromCovCheckTest.json", cc.PathMap.JSON())
+	IsTrue(t, len(cc.ServiceStats) == 1, "Coverage stats not calculated")
+}
+
+func TestNewAddEntriesRewritesEntries(t *testing.T) {
+	c := Config{}
+	PetstoreConfig(t, &c)
+	c.Rewrites = []logs.RewriteRule{{Match: "^/v2/", Replace: "/petstore/"}}
+	u, err := url.Parse("http://localhost/v2/pet/findByStatus")
+	AssertSuccess(t, err)
+	le := logs.RequestLogEntry{Method: "GET", Response: "200"}
+	err = le.SetURL(u, logs.Normalisation{})
+	AssertSuccess(t, err)
+	cc, err := New(c).AddEntries(le).Result()
+	AssertSuccess(t, err)
+	v := cc.PathMap.Services["petstore"].PathItems["pet"].PathItems["findByStatus"].Verbs["GET"]
+	AreEqual(t, 1, v.Responses["200"].Covered, "Entry not rewritten and checked")
+	AreEqual(t, 1, cc.OperationsCovered, "Wrong number of operations called")
+}
+
+func TestNewReturnsErrorFromResult(t *testing.T) {
+	c := Config{}
+	PetstoreConfig(t, &c)
+	c.Services[0].Swagger = FileURL("DoesntExist.json")
+	_, err := New(c).AddLogs().Result()
+	IsTrue(t, err != nil, "Error reading the Swagger not returned")
+
+	c = Config{}
+	PetstoreConfig(t, &c)
+	c.TransactionLogs[0].LogURL = FileURL("DoesntExist.txt")
+	_, err = New(c).AddLogs().Result()
+	IsTrue(t, err != nil, "Error reading the log not returned")
+}
+
+func TestNavigatePathMapCountsOperationsCalled(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/pet":{"get":{"responses":{}},"post":{"responses":{"200":{"description":"ok"}}}},
+		"/store":{"get":{"responses":{"200":{"description":"ok"}}}}
+	}}`
+	cc := NewCovChecker()
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "200"})
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"user"}, Response: "200"})
+	cc.NavigatePathMap()
+	AreEqual(t, 3, cc.Operations, "Undocumented operations should not be counted")
+	AreEqual(t, 1, cc.OperationsCovered, "Wrong number of operations called")
+	AreEqual(t, 3, cc.ServiceStats[0].Operations, "Wrong number of service operations")
+	AreEqual(t, 1, cc.ServiceStats[0].OperationsCovered, "Wrong number of service operations called")
+	for _, es := range cc.ServiceStats[0].Endpoints {
+		if es.Path == "/pet" {
+			for _, vs := range es.Verbs {
+				if vs.Method == "GET" {
+					IsTrue(t, vs.Called, "GET /pet not called")
+					//The called point and the undocumented 200 response
+					AreEqual(t, 2, vs.Total, "Wrong number of points")
+					AreEqual(t, 2, vs.Covered, "Wrong number of covered points")
+				}
+			}
+		}
+	}
+}
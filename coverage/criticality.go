@@ -0,0 +1,87 @@
+package coverage
+
+import (
+	"sort"
+	"strings"
+)
+
+//DefaultCriticality is the criticality of an operation that does not set one
+const DefaultCriticality = "medium"
+
+//Criticality maps a criticality level to the factor the coverage points of an
+//operation at that level are multiplied by in the risk-weighted coverage score.
+//Levels that are not listed use the factor of the built in levels
+type Criticality map[string]float64
+
+//BuiltInCriticality contains the factors of the built in criticality levels
+var BuiltInCriticality = Criticality{
+	"low":      0.5,
+	"medium":   1,
+	"high":     3,
+	"critical": 5,
+}
+
+//Factor returns the factor of the passed criticality level. Unknown levels have
+//the factor of the default level
+func (c Criticality) Factor(level string) float64 {
+	level = strings.ToLower(level)
+	if level == "" {
+		level = DefaultCriticality
+	}
+	if factor, exists := c[level]; exists {
+		return factor
+	}
+	if factor, exists := BuiltInCriticality[level]; exists {
+		return factor
+	}
+	return c.Factor(DefaultCriticality)
+}
+
+//CriticalGap is an operation with a higher than default criticality that is not
+//fully covered
+type CriticalGap struct {
+	Service     string
+	Path        string
+	Method      string
+	OperationID string
+	Criticality string
+	Factor      float64
+	Coverage    float64
+}
+
+//AddRiskStats adds the points of the verb stats multiplied by the factor of the
+//verb's criticality to the risk-weighted totals, and records the verb as a gap if
+//it is more critical than the default and not fully covered
+func (cc *CovCheckerInfo) AddRiskStats(ss *ServiceStat, vs VerbStat) {
+	factor := cc.Criticality.Factor(vs.Criticality)
+	if vs.Criticality != "" {
+		cc.HasCriticality = true
+	}
+	ss.Risk += factor * float64(vs.Covered)
+	ss.RiskTot += factor * float64(vs.Total)
+	cc.Risk += factor * float64(vs.Covered)
+	cc.RiskTot += factor * float64(vs.Total)
+	if vs.Covered < vs.Total && factor > cc.Criticality.Factor(DefaultCriticality) {
+		cc.CriticalGaps = append(cc.CriticalGaps, CriticalGap{
+			Service:     vs.Service,
+			Path:        vs.Path,
+			Method:      vs.Method,
+			OperationID: vs.OperationID,
+			Criticality: strings.ToLower(vs.Criticality),
+			Factor:      factor,
+			Coverage:    vs.CoverageRatio(),
+		})
+	}
+}
+
+//SortCriticalGaps sorts the gaps so that the most critical operations come first,
+//and the least covered of those with the same criticality
+func (cc *CovCheckerInfo) SortCriticalGaps() {
+	sort.SliceStable(cc.CriticalGaps, func(i, j int) bool {
+		gi, gj := cc.CriticalGaps[i], cc.CriticalGaps[j]
+		if gi.Factor != gj.Factor {
+			return gi.Factor > gj.Factor
+		}
+		return gi.Coverage < gj.Coverage
+	})
+}
@@ -0,0 +1,39 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func TestCriticalityFactor(t *testing.T) {
+	c := Criticality{"high": 10}
+	AreEqual(t, 10.0, c.Factor("HIGH"), "Configured factor not used")
+	AreEqual(t, 5.0, c.Factor("critical"), "Built in factor not used")
+	AreEqual(t, 1.0, c.Factor(""), "Wrong factor for no criticality")
+	AreEqual(t, 1.0, c.Factor("unknown"), "Wrong factor for unknown criticality")
+}
+
+func TestNavigatePathMapRiskWeighted(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/pet":{"get":{"x-criticality":"low","responses":{"200":{"description":"ok"}}}},
+		"/payment":{"post":{"x-criticality":"critical","operationId":"pay","responses":{"200":{"description":"ok"},"402":{"description":"declined"}}}},
+		"/login":{"post":{"x-criticality":"high","responses":{"200":{"description":"ok"}}}},
+		"/store":{"get":{"responses":{"200":{"description":"ok"}}}}
+	}}`
+	cc := NewCovChecker()
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "200"})
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "POST", Service: "petstore", PathElements: []string{"payment"}, Response: "200"})
+	cc.NavigatePathMap()
+	IsTrue(t, cc.HasCriticality, "Criticality not detected")
+	AreEqual(t, 4.0/9.0, cc.Coverage, "Wrong coverage")
+	//low 0.5*2 covered + critical 5*2 covered out of 0.5*2 + 5*3 + 3*2 + 1*2
+	AreEqual(t, 11.0/24.0, cc.Risk, "Wrong risk-weighted coverage")
+	AreEqual(t, 2, len(cc.CriticalGaps), "Wrong number of critical gaps")
+	AreEqual(t, "/payment", cc.CriticalGaps[0].Path, "Most critical gap not first")
+	AreEqual(t, "pay", cc.CriticalGaps[0].OperationID, "Wrong operationId")
+	AreEqual(t, "/login", cc.CriticalGaps[1].Path, "Wrong second gap")
+	AreEqual(t, 0.0, cc.CriticalGaps[1].Coverage, "Wrong gap coverage")
+}
@@ -0,0 +1,32 @@
+package coverage
+
+import (
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//DeprecatedCall records a deprecated operation that has been called in the logs
+type DeprecatedCall struct {
+	Service string
+	Path    string
+	Method  string
+	Calls   int
+}
+
+//CheckDeprecated records a warning if the passed verb is deprecated and has been
+//called. It returns true if the verb should be left out of the coverage stats,
+//which deprecated verbs are unless the configuration includes them
+func (cc *CovCheckerInfo) CheckDeprecated(service, path string, verb *pathmap.Verb) bool {
+	if !verb.Deprecated {
+		return false
+	}
+	calls := verb.Calls()
+	if calls > 0 {
+		cc.DeprecatedCalls = append(cc.DeprecatedCalls, DeprecatedCall{
+			Service: service,
+			Path:    path,
+			Method:  verb.Name,
+			Calls:   calls,
+		})
+	}
+	return !cc.IncludeDeprecated
+}
@@ -0,0 +1,41 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func NewTestDeprecatedCovChecker(t *testing.T) *CovCheckerInfo {
+	c := `{"swagger":"2.0","paths":{
+		"/pet":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/pet/findByTags":{"get":{"deprecated":true,"responses":{"200":{"description":"ok"},"400":{"description":"bad"}}}},
+		"/pet/findByName":{"get":{"deprecated":true,"responses":{"200":{"description":"ok"}}}}
+	}}`
+	cc := NewCovChecker()
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	for _, code := range []string{"200", "400"} {
+		cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "findByTags"}, Response: code})
+	}
+	return cc
+}
+
+func TestNavigatePathMapExcludesDeprecated(t *testing.T) {
+	cc := NewTestDeprecatedCovChecker(t)
+	cc.NavigatePathMap()
+	AreEqual(t, 1, len(cc.ServiceStats[0].Endpoints), "Deprecated endpoints not excluded")
+	AreEqual(t, 2.0, cc.TotalPoint, "Deprecated endpoints counted")
+	AreEqual(t, 1, len(cc.DeprecatedCalls), "Wrong number of deprecated calls")
+	AreEqual(t, "/pet/findByTags", cc.DeprecatedCalls[0].Path, "Wrong deprecated call path")
+	AreEqual(t, 2, cc.DeprecatedCalls[0].Calls, "Wrong number of calls")
+}
+
+func TestNavigatePathMapIncludesDeprecated(t *testing.T) {
+	cc := NewTestDeprecatedCovChecker(t)
+	cc.IncludeDeprecated = true
+	cc.NavigatePathMap()
+	AreEqual(t, 3, len(cc.ServiceStats[0].Endpoints), "Deprecated endpoints not included")
+	AreEqual(t, 7.0, cc.TotalPoint, "Deprecated endpoints not counted")
+	AreEqual(t, 1, len(cc.DeprecatedCalls), "Wrong number of deprecated calls")
+}
@@ -0,0 +1,71 @@
+package coverage
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//IgnoreRule excludes matching items from the coverage report and from both the
+//coverage and documented statistics. Every field that is set must match, so a rule
+//with only a service ignores the whole service, and a rule with a path and method
+//ignores that verb on every matching endpoint. Path is a glob where "*" matches
+//within a single path element, "**" matches across elements, and any path
+//parameter e.g. "{petId}" matches any parameter
+type IgnoreRule struct {
+	Service  string `json:"service"`
+	Path     string `json:"path"`
+	Method   string `json:"method"`
+	Response string `json:"response"`
+}
+
+//Matches returns true if this rule ignores the passed item. An empty path, method
+//or response refers to the whole service, endpoint or verb respectively. The path
+//is matched ignoring case when caseInsensitive is set
+func (ir IgnoreRule) Matches(service, path, method, response string, caseInsensitive bool) bool {
+	if ir.Service != "" && ir.Service != service {
+		return false
+	}
+	if ir.Path != "" && (path == "" || !GlobMatch(ir.Path, path, caseInsensitive)) {
+		return false
+	}
+	if ir.Method != "" && !strings.EqualFold(ir.Method, method) {
+		return false
+	}
+	if ir.Response != "" && ir.Response != response {
+		return false
+	}
+	return true
+}
+
+//IsIgnored returns true if any of the ignore rules matches the passed item
+func (cc *CovCheckerInfo) IsIgnored(service, path, method, response string) bool {
+	caseInsensitive := cc.PathMap != nil && cc.PathMap.CaseInsensitive
+	for _, ir := range cc.Ignore {
+		if ir.Matches(service, path, method, response, caseInsensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+var globParamRegexp = regexp.MustCompile(`\\\{[^/]*\\\}`)
+
+//GlobMatch returns true if the path matches the glob pattern, ignoring case when
+//caseInsensitive is set
+func GlobMatch(pattern, path string, caseInsensitive bool) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = globParamRegexp.ReplaceAllString(expr, regexp.QuoteMeta(pathmap.ParameterisedItemKey))
+	expr = strings.ReplaceAll(expr, `\*\*`, `.*`)
+	expr = strings.ReplaceAll(expr, `\*`, `[^/]*`)
+	expr = "^" + expr + "$"
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+	r, err := regexp.Compile(expr)
+	if err != nil {
+		return false
+	}
+	return r.MatchString(path)
+}
@@ -0,0 +1,69 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+func TestGlobMatch(t *testing.T) {
+	IsTrue(t, GlobMatch("/health", "/health", false), "Literal path not matched")
+	IsTrue(t, GlobMatch("/debug/*", "/debug/vars", false), "Single element wildcard not matched")
+	IsFalse(t, GlobMatch("/debug/*", "/debug/pprof/heap", false), "Single element wildcard matched several elements")
+	IsTrue(t, GlobMatch("/debug/**", "/debug/pprof/heap", false), "Multiple element wildcard not matched")
+	IsTrue(t, GlobMatch("/pet/{petId}", "/pet/{*}", false), "Path parameter not matched")
+	IsFalse(t, GlobMatch("/pet/{petId}", "/pet/findByStatus", false), "Path parameter matched a literal element")
+	IsFalse(t, GlobMatch("/Pet/FindBy*", "/pet/findbystatus", false), "Case sensitive glob matched a different case")
+	IsTrue(t, GlobMatch("/Pet/FindBy*", "/pet/findbystatus", true), "Case insensitive glob not matched")
+}
+
+func TestIgnoreRuleMatches(t *testing.T) {
+	IsTrue(t, IgnoreRule{Service: "petstore"}.Matches("petstore", "", "", "", false), "Service rule did not match service")
+	IsTrue(t, IgnoreRule{Method: "options"}.Matches("petstore", "/pet", "OPTIONS", "", false), "Method rule did not match verb")
+	IsFalse(t, IgnoreRule{Method: "OPTIONS"}.Matches("petstore", "", "", "", false), "Method rule matched whole service")
+	IsTrue(t, IgnoreRule{Response: "500"}.Matches("petstore", "/pet", "GET", "500", false), "Response rule did not match response")
+	IsFalse(t, IgnoreRule{Response: "500"}.Matches("petstore", "/pet", "GET", "", false), "Response rule matched whole verb")
+}
+
+func TestRulesMatchPathsIgnoringCase(t *testing.T) {
+	cc := NewCovChecker()
+	cc.Ignore = []IgnoreRule{{Path: "/Debug/**"}}
+	cc.Ownership.Rules = []OwnerRule{{Service: "*", Path: "/Pet/**", Owner: "pets-team"}}
+	IsFalse(t, cc.IsIgnored("petstore", "/debug/vars", "", ""), "Path ignored with a different case")
+	AreEqual(t, UnownedOwner, cc.OwnerOf("petstore", "/pet/{*}", &pathmap.Verb{}), "Owner matched with a different case")
+
+	cc.PathMap.CaseInsensitive = true
+	IsTrue(t, cc.IsIgnored("petstore", "/debug/vars", "", ""), "Path not ignored ignoring case")
+	AreEqual(t, "pets-team", cc.OwnerOf("petstore", "/pet/{*}", &pathmap.Verb{}), "Owner not matched ignoring case")
+}
+
+func TestNavigatePathMapIgnoresItems(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/health":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/debug/vars":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/pet":{"get":{"responses":{"200":{"description":"ok"},"500":{"description":"error"}}},
+			"delete":{"x-coverage-ignore":true,"responses":{"200":{"description":"ok"}}}}
+	}}`
+	cc := NewCovChecker()
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "200"})
+	cc.Ignore = []IgnoreRule{
+		{Path: "/health"},
+		{Service: "petstore", Path: "/debug/**"},
+		{Path: "/pet", Method: "GET", Response: "500"},
+	}
+	cc.NavigatePathMap()
+	AreEqual(t, 1, len(cc.ServiceStats), "Wrong number of services")
+	ss := cc.ServiceStats[0]
+	AreEqual(t, 1, len(ss.Endpoints), "Wrong number of endpoints")
+	AreEqual(t, "/pet", ss.Endpoints[0].Path, "Wrong endpoint")
+	AreEqual(t, 1, len(ss.Endpoints[0].Verbs), "Wrong number of verbs")
+	AreEqual(t, 1, len(ss.Endpoints[0].Verbs[0].Responses), "Wrong number of responses")
+	AreEqual(t, 1.0, cc.Coverage, "Wrong coverage")
+
+	cc.Ignore = []IgnoreRule{{Service: "petstore"}}
+	cc.NavigatePathMap()
+	AreEqual(t, 0, len(cc.ServiceStats), "Service not ignored")
+}
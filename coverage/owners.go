@@ -0,0 +1,164 @@
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codeafix/apicovchk/filereader"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//UnownedOwner is the name of the owner group that collects the operations that
+//no team owns
+const UnownedOwner = "(unowned)"
+
+//Ownership configures how operations are mapped to the teams that own them and the
+//minimum coverage each team is expected to reach. File is the URL of an
+//ownership file that adds to the rules
+type Ownership struct {
+	File             string             `json:"file"`
+	Rules            []OwnerRule        `json:"rules"`
+	Thresholds       map[string]float64 `json:"thresholds"`
+	DefaultThreshold float64            `json:"defaultThreshold"`
+}
+
+//OwnerRule assigns the operations of the service whose path matches the glob in
+//Path to Owner. An empty or "*" service matches every service, and the path glob
+//is matched in the same way as an IgnoreRule path
+type OwnerRule struct {
+	Service string `json:"service"`
+	Path    string `json:"path"`
+	Owner   string `json:"owner"`
+}
+
+//OwnerStat collects the coverage for all of the operations owned by a team
+type OwnerStat struct {
+	Name         string
+	Operations   int
+	Coverage     float64
+	Undocumented float64
+	TotalPoint   float64
+	Threshold    float64
+}
+
+//BelowThreshold returns true if the owner's coverage is less than its threshold
+func (ost *OwnerStat) BelowThreshold() bool {
+	return ost.Coverage < ost.Threshold
+}
+
+//Threshold returns the minimum coverage expected of the owner
+func (o Ownership) Threshold(owner string) float64 {
+	if t, exists := o.Thresholds[owner]; exists {
+		return t
+	}
+	return o.DefaultThreshold
+}
+
+//ReadOwnershipFile reads the rules from the ownership file at the passed URL
+func ReadOwnershipFile(urlstring string) ([]OwnerRule, error) {
+	ur, err := filereader.NewURLReader(urlstring)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ur.ReadFromURL()
+	if err != nil {
+		return nil, err
+	}
+	return ParseOwnershipFile(c)
+}
+
+//ParseOwnershipFile parses the content of an ownership file. Each line contains a
+//service name, a path glob and an owner separated by white space, for example
+//"petstore /pet/** team-pets". Blank lines and lines starting with "#" are skipped
+func ParseOwnershipFile(c []byte) ([]OwnerRule, error) {
+	rules := []OwnerRule{}
+	scanner := bufio.NewScanner(bytes.NewReader(c))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("Invalid ownership rule on line %d: '%s'", line, text)
+		}
+		rules = append(rules, OwnerRule{Service: fields[0], Path: fields[1], Owner: fields[2]})
+	}
+	return rules, scanner.Err()
+}
+
+//Matches returns true if the rule assigns the passed endpoint to its owner. The
+//path is matched ignoring case when caseInsensitive is set
+func (or OwnerRule) Matches(service, path string, caseInsensitive bool) bool {
+	if or.Service != "" && or.Service != "*" && or.Service != service {
+		return false
+	}
+	return GlobMatch(or.Path, path, caseInsensitive)
+}
+
+//OwnerOf returns the owner of the passed verb. The x-owner extension of the
+//operation takes precedence, otherwise the last matching ownership rule wins so
+//that more specific rules can be listed after general ones
+func (cc *CovCheckerInfo) OwnerOf(service, path string, verb *pathmap.Verb) string {
+	if verb.Owner != "" {
+		return verb.Owner
+	}
+	owner := UnownedOwner
+	caseInsensitive := cc.PathMap != nil && cc.PathMap.CaseInsensitive
+	for _, or := range cc.Ownership.Rules {
+		if or.Matches(service, path, caseInsensitive) {
+			owner = or.Owner
+		}
+	}
+	return owner
+}
+
+//AddOwnerStats adds the verb stats to the stats of the verb's owner
+func (cc *CovCheckerInfo) AddOwnerStats(vs VerbStat) {
+	var owner *OwnerStat
+	for _, existing := range cc.OwnerStats {
+		if existing.Name == vs.Owner {
+			owner = existing
+			break
+		}
+	}
+	if owner == nil {
+		owner = &OwnerStat{
+			Name:      vs.Owner,
+			Threshold: cc.Ownership.Threshold(vs.Owner),
+		}
+		cc.OwnerStats = append(cc.OwnerStats, owner)
+	}
+	owner.Operations++
+	owner.Coverage += float64(vs.Covered)
+	owner.Undocumented += float64(vs.Undocumented)
+	owner.TotalPoint += float64(vs.Total)
+}
+
+//CalculateOwnerStats turns the point counts of each owner into coverage ratios
+//and sorts the owners by name
+func (cc *CovCheckerInfo) CalculateOwnerStats() {
+	for _, owner := range cc.OwnerStats {
+		owner.Coverage = Ratio(owner.Coverage, owner.TotalPoint)
+		owner.Undocumented = Ratio(owner.Undocumented, owner.TotalPoint)
+	}
+	sort.Slice(cc.OwnerStats, func(i, j int) bool {
+		return cc.OwnerStats[i].Name < cc.OwnerStats[j].Name
+	})
+}
+
+//OwnersBelowThreshold returns the owners whose coverage is below their threshold
+func (cc *CovCheckerInfo) OwnersBelowThreshold() []*OwnerStat {
+	below := []*OwnerStat{}
+	for _, owner := range cc.OwnerStats {
+		if owner.BelowThreshold() {
+			below = append(below, owner)
+		}
+	}
+	return below
+}
@@ -0,0 +1,59 @@
+package coverage
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func TestParseOwnershipFile(t *testing.T) {
+	c := []byte(`# Owners of the petstore
+petstore /pet/** team-pets
+
+*        /store/* team-orders
+`)
+	rules, err := ParseOwnershipFile(c)
+	AssertSuccess(t, err)
+	AreEqual(t, 2, len(rules), "Wrong number of rules")
+	AreEqual(t, OwnerRule{Service: "petstore", Path: "/pet/**", Owner: "team-pets"}, rules[0], "Wrong first rule")
+	AreEqual(t, "*", rules[1].Service, "Wrong service for second rule")
+	_, err = ParseOwnershipFile([]byte("petstore /pet/**"))
+	IsTrue(t, err != nil, "Expected an error for a rule without an owner")
+}
+
+func TestNavigatePathMapRollsUpOwners(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/pet":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/pet/{petId}":{"get":{"x-owner":"team-vets","responses":{"200":{"description":"ok"}}}},
+		"/store/order":{"get":{"responses":{"200":{"description":"ok"},"404":{"description":"none"}}}},
+		"/user":{"get":{"responses":{"200":{"description":"ok"}}}}
+	}}`
+	cc := NewCovChecker()
+	cc.Ownership = Ownership{
+		Rules: []OwnerRule{
+			{Path: "/**", Owner: "team-core"},
+			{Service: "petstore", Path: "/pet", Owner: "team-pets"},
+			{Service: "petstore", Path: "/store/**", Owner: "team-orders"},
+		},
+		Thresholds:       map[string]float64{"team-orders": 0.8},
+		DefaultThreshold: 0.5,
+	}
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"store", "order"}, Response: "200"})
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"user"}, Response: "200"})
+	cc.NavigatePathMap()
+	AreEqual(t, 4, len(cc.OwnerStats), "Wrong number of owners")
+	names := []string{}
+	for _, owner := range cc.OwnerStats {
+		names = append(names, owner.Name)
+	}
+	AreEqual(t, "team-core,team-orders,team-pets,team-vets", strings.Join(names, ","), "Wrong owners")
+	AreEqual(t, 1.0, cc.OwnerStats[0].Coverage, "Wrong team-core coverage")
+	AreEqual(t, 2.0/3.0, cc.OwnerStats[1].Coverage, "Wrong team-orders coverage")
+	AreEqual(t, 0.8, cc.OwnerStats[1].Threshold, "Wrong team-orders threshold")
+	below := cc.OwnersBelowThreshold()
+	AreEqual(t, 3, len(below), "Wrong number of owners below threshold")
+	AreEqual(t, "team-orders", below[0].Name, "Wrong owner below threshold")
+}
@@ -0,0 +1,46 @@
+package coverage
+
+import (
+	"fmt"
+
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//Policy contains the rules that every documented operation is expected to satisfy
+type Policy struct {
+	RequiredResponseClasses []string `json:"requiredResponseClasses"`
+}
+
+//PolicyViolation records an operation that does not satisfy the coverage policy
+type PolicyViolation struct {
+	Service string
+	Path    string
+	Method  string
+	Message string
+}
+
+//ClassStat collects the coverage counts of the responses in one class of
+//response codes
+type ClassStat struct {
+	Total   int
+	Covered int
+}
+
+//CheckPolicy adds a violation for each response class that the policy requires
+//to be covered but for which no response was logged against the verb
+func (cc *CovCheckerInfo) CheckPolicy(service, path string, verb *pathmap.Verb, vs VerbStat) {
+	if !verb.Documented {
+		return
+	}
+	for _, class := range cc.Policy.RequiredResponseClasses {
+		cs := vs.Classes[class]
+		if cs == nil || cs.Covered == 0 {
+			cc.Violations = append(cc.Violations, PolicyViolation{
+				Service: service,
+				Path:    path,
+				Method:  verb.Name,
+				Message: fmt.Sprintf("No %s response covered", class),
+			})
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+func TestResponseClass(t *testing.T) {
+	AreEqual(t, pathmap.SuccessClass, pathmap.ResponseClass("204"), "Wrong class for 204")
+	AreEqual(t, pathmap.ClientErrorClass, pathmap.ResponseClass("404"), "Wrong class for 404")
+	AreEqual(t, pathmap.ServerErrorClass, pathmap.ResponseClass("503"), "Wrong class for 503")
+	AreEqual(t, "", pathmap.ResponseClass("default"), "Wrong class for default")
+}
+
+func TestCheckPolicyReportsMissingClientErrors(t *testing.T) {
+	c := Config{}
+	PetstoreConfig(t, &c)
+	c.Policy = Policy{
+		RequiredResponseClasses: []string{pathmap.ClientErrorClass},
+	}
+	cc := NewCovChecker()
+	err := cc.CheckCoverage(c)
+	AssertSuccess(t, err)
+	cc.NavigatePathMap()
+	found := map[string]bool{}
+	for _, pv := range cc.Violations {
+		found[pv.Method+" "+pv.Path] = true
+		AreEqual(t, "No 4xx response covered", pv.Message, "Wrong violation message")
+	}
+	IsTrue(t, found["GET /store/inventory"], "Expected violation for GET /store/inventory")
+	IsFalse(t, found["GET /pet/findByStatus"], "Unexpected violation for GET /pet/findByStatus")
+}
@@ -0,0 +1,56 @@
+package coverage
+
+import (
+	"sort"
+)
+
+//UntaggedTag is the name of the tag group that collects the operations that
+//have no tags in their Swagger description
+const UntaggedTag = "(untagged)"
+
+//TagStat collects the coverage for all of the operations that share a Swagger tag
+type TagStat struct {
+	Name         string
+	Verbs        []VerbStat
+	Coverage     float64
+	Undocumented float64
+	TotalPoint   float64
+}
+
+//AddTagStats adds the verb stats to the stats of each of the verb's tags. A verb
+//with more than one tag counts towards each of them
+func (cc *CovCheckerInfo) AddTagStats(vs VerbStat) {
+	tags := vs.Tags
+	if len(tags) == 0 {
+		tags = []string{UntaggedTag}
+	}
+	for _, tag := range tags {
+		var ts *TagStat
+		for _, existing := range cc.TagStats {
+			if existing.Name == tag {
+				ts = existing
+				break
+			}
+		}
+		if ts == nil {
+			ts = &TagStat{Name: tag}
+			cc.TagStats = append(cc.TagStats, ts)
+		}
+		ts.Verbs = append(ts.Verbs, vs)
+		ts.Coverage += float64(vs.Covered)
+		ts.Undocumented += float64(vs.Undocumented)
+		ts.TotalPoint += float64(vs.Total)
+	}
+}
+
+//CalculateTagStats turns the point counts of each tag into coverage ratios and
+//sorts the tags by name
+func (cc *CovCheckerInfo) CalculateTagStats() {
+	for _, ts := range cc.TagStats {
+		ts.Coverage = Ratio(ts.Coverage, ts.TotalPoint)
+		ts.Undocumented = Ratio(ts.Undocumented, ts.TotalPoint)
+	}
+	sort.Slice(cc.TagStats, func(i, j int) bool {
+		return cc.TagStats[i].Name < cc.TagStats[j].Name
+	})
+}
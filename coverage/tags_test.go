@@ -0,0 +1,31 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func TestNavigatePathMapGroupsByTag(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/pet":{"get":{"tags":["pet"],"operationId":"listPets","summary":"List pets","responses":{"200":{"description":"ok"}}},
+			"post":{"tags":["pet","admin"],"operationId":"addPet","responses":{"200":{"description":"ok"},"400":{"description":"bad"}}}},
+		"/store":{"get":{"responses":{"200":{"description":"ok"}}}}
+	}}`
+	cc := NewCovChecker()
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	verb := cc.PathMap.Services["petstore"].PathItems["pet"].Verbs["GET"]
+	AreEqual(t, "listPets", verb.OperationID, "OperationId not read")
+	AreEqual(t, "List pets", verb.Summary, "Summary not read")
+	cc.PathMap.CheckRequestLogEntry(logs.RequestLogEntry{Method: "POST", Service: "petstore", PathElements: []string{"pet"}, Response: "200"})
+	cc.NavigatePathMap()
+	AreEqual(t, 3, len(cc.TagStats), "Wrong number of tags")
+	AreEqual(t, UntaggedTag, cc.TagStats[0].Name, "Tags not sorted")
+	AreEqual(t, "admin", cc.TagStats[1].Name, "Tags not sorted")
+	AreEqual(t, 2.0/3.0, cc.TagStats[1].Coverage, "Wrong admin coverage")
+	AreEqual(t, "pet", cc.TagStats[2].Name, "Tags not sorted")
+	AreEqual(t, 2, len(cc.TagStats[2].Verbs), "Wrong number of pet operations")
+	AreEqual(t, 0.4, cc.TagStats[2].Coverage, "Wrong pet coverage")
+	AreEqual(t, "/pet", cc.TagStats[2].Verbs[0].Path, "Verb path not set")
+}
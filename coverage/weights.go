@@ -0,0 +1,55 @@
+package coverage
+
+import (
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//Categories of coverage point that can be given a weight in the options file
+const (
+	//RequiredParameterWeight is the weight of a required query or formData parameter
+	RequiredParameterWeight = "requiredParameter"
+	//OptionalParameterWeight is the weight of an optional query or formData parameter
+	OptionalParameterWeight = "optionalParameter"
+	//SuccessResponseWeight is the weight of a 2xx response
+	SuccessResponseWeight = "successResponse"
+	//ClientErrorResponseWeight is the weight of a 4xx response
+	ClientErrorResponseWeight = "clientErrorResponse"
+	//OtherResponseWeight is the weight of any other response, including 5xx and default
+	OtherResponseWeight = "otherResponse"
+	//SecurityWeight is the weight of each of the security points of a secured operation
+	SecurityWeight = "security"
+	//CalledWeight is the weight of the point for an operation having been called
+	CalledWeight = "called"
+)
+
+//Weights maps a category of coverage point to the weight it contributes to the
+//weighted coverage score. Categories that are not listed have a weight of 1
+type Weights map[string]float64
+
+//Weight returns the weight of the passed category
+func (w Weights) Weight(category string) float64 {
+	weight, exists := w[category]
+	if !exists {
+		return 1
+	}
+	return weight
+}
+
+//ResponseWeight returns the weight of the passed response
+func (w Weights) ResponseWeight(response *pathmap.Response) float64 {
+	switch pathmap.ResponseClass(response.Response) {
+	case pathmap.SuccessClass:
+		return w.Weight(SuccessResponseWeight)
+	case pathmap.ClientErrorClass:
+		return w.Weight(ClientErrorResponseWeight)
+	}
+	return w.Weight(OtherResponseWeight)
+}
+
+//ParameterWeight returns the weight of the passed parameter
+func (w Weights) ParameterWeight(param *pathmap.QueryParameter) float64 {
+	if param.Required {
+		return w.Weight(RequiredParameterWeight)
+	}
+	return w.Weight(OptionalParameterWeight)
+}
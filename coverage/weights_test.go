@@ -0,0 +1,38 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+func TestWeightDefaultsToOne(t *testing.T) {
+	w := Weights{RequiredParameterWeight: 3}
+	AreEqual(t, 3.0, w.Weight(RequiredParameterWeight), "Wrong configured weight")
+	AreEqual(t, 1.0, w.Weight(OptionalParameterWeight), "Wrong default weight")
+	AreEqual(t, 1.0, Weights(nil).Weight(SecurityWeight), "Wrong default weight without configuration")
+}
+
+func TestCalculateVerbStatsWeighted(t *testing.T) {
+	v := pathmap.NewVerb("GET", true, []string{}, []string{})
+	v.Responses["200"] = &pathmap.Response{Response: "200", Covered: 1, Documented: true}
+	v.Responses["404"] = &pathmap.Response{Response: "404", Documented: true}
+	v.Responses["500"] = &pathmap.Response{Response: "500", Documented: true}
+	v.QueryParameters["id"] = &pathmap.QueryParameter{Key: "id", Covered: 1, Documented: true, Required: true}
+	v.QueryParameters["filter"] = &pathmap.QueryParameter{Key: "filter", Documented: true}
+	cc := NewCovChecker()
+	cc.Weights = Weights{
+		SuccessResponseWeight:     4,
+		ClientErrorResponseWeight: 2,
+		OtherResponseWeight:       0.5,
+		RequiredParameterWeight:   3,
+		OptionalParameterWeight:   0.5,
+		CalledWeight:              2,
+	}
+	vs := cc.CalculateVerbStats(v)
+	AreEqual(t, 6, vs.Total, "Wrong total")
+	AreEqual(t, 3, vs.Covered, "Wrong covered")
+	AreEqual(t, 12.0, vs.WeightedTot, "Wrong weighted total")
+	AreEqual(t, 9.0, vs.WeightedCov, "Wrong weighted covered")
+}
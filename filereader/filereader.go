@@ -1,4 +1,6 @@
-package main
+//Package filereader reads the content of local files and web resources that are
+//referenced by URL, such as Swagger files and transaction logs
+package filereader
 
 import (
 	"fmt"
@@ -1,11 +1,12 @@
-package main
+package filereader
 
 import (
 	"fmt"
 	"net/url"
-	"os"
 	"strings"
 	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
 )
 
 type TestFileReaderInfo struct {
@@ -97,8 +98,7 @@ func TestReadFromWeb(t *testing.T) {
 }
 
 func TestReadFromFile(t *testing.T) {
-	dir, err := os.Getwd()
-	filepath := fmt.Sprintf("file:///%s/swagger.json", strings.Replace(dir, "\\", "/", -1))
+	filepath := FileURL("swagger.json")
 	sr, err := NewFileReader(filepath)
 	AssertSuccess(t, err)
 	c, err := sr.ReadFromFile()
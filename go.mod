@@ -2,12 +2,15 @@ module github.com/codeafix/apicovchk
 
 go 1.21.6
 
+require (
+	github.com/go-openapi/spec v0.20.14
+	github.com/google/uuid v1.6.0
+)
+
 require (
 	github.com/go-openapi/jsonpointer v0.20.2 // indirect
 	github.com/go-openapi/jsonreference v0.20.4 // indirect
-	github.com/go-openapi/spec v0.20.14 // indirect
 	github.com/go-openapi/swag v0.22.6 // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
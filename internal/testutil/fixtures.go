@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+//SwaggerMapper maps the paths of a Swagger definition, it is implemented by the
+//pathmap.PathMap
+type SwaggerMapper interface {
+	MapSwaggerPaths(route string, swgr *spec.Swagger) error
+}
+
+//MapSwagger unmarshals the Swagger json and maps its paths under the service name.
+//Each prepare function is called with the Swagger and its raw content before the
+//paths are mapped, e.g. swagger.AddResponseRanges
+func MapSwagger(t *testing.T, pm SwaggerMapper, name, content string, prepare ...func(*spec.Swagger, []byte) error) *spec.Swagger {
+	t.Helper()
+	swag := &spec.Swagger{}
+	err := swag.UnmarshalJSON([]byte(content))
+	AssertSuccess(t, err)
+	for _, p := range prepare {
+		err = p(swag, []byte(content))
+		AssertSuccess(t, err)
+	}
+	err = pm.MapSwaggerPaths(name, swag)
+	AssertSuccess(t, err)
+	return swag
+}
+
+//PetstoreConfig unmarshals the options for the petstore service and the example
+//petstore-report.txt Transaction log into conf, which is a *coverage.Config
+func PetstoreConfig(t *testing.T, conf interface{}) {
+	t.Helper()
+	c := fmt.Sprintf(`{
+		"transactionLogFiles": [{"logURL": %q, "logType": "Transaction"}],
+		"services": [{"routePath": "petstore", "swagger": %q}]
+	}`, FileURL("petstore-report.txt"), FileURL("PetstoreSwagger.json"))
+	err := json.Unmarshal([]byte(c), conf)
+	AssertSuccess(t, err)
+}
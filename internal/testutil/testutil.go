@@ -0,0 +1,101 @@
+//Package testutil contains the assertion helpers shared by the tests of the
+//apicovchk packages. The gold files and example data used by the tests are kept in
+//the root of the module, and generated files are written to its temp directory.
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//IsFalse fails the test if the condition is true
+func IsFalse(t *testing.T, condition bool, msg string) {
+	t.Helper()
+	if condition {
+		t.Error(msg)
+	}
+}
+
+//IsTrue fails the test if the condition is false
+func IsTrue(t *testing.T, condition bool, msg string) {
+	t.Helper()
+	if !condition {
+		t.Error(msg)
+	}
+}
+
+//AreEqual fails the test if the expected and actual values are not equal
+func AreEqual(t *testing.T, expected interface{}, actual interface{}, msg string) {
+	t.Helper()
+	if expected != actual {
+		t.Errorf("%s Expected = %v,%T Actual = %v,%T", msg, expected, expected, actual, actual)
+	}
+}
+
+//NotEqual fails the test if the expected and actual values are equal
+func NotEqual(t *testing.T, expected interface{}, actual interface{}, msg string) {
+	t.Helper()
+	if expected == actual {
+		t.Errorf("%s Expected = %v Actual = %v", msg, expected, actual)
+	}
+}
+
+//AssertSuccess fails the test if the error is not nil
+func AssertSuccess(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Error(err.Error())
+	}
+}
+
+//CheckGold fails the test if the json is different from the content of the gold
+//file in the root of the module. The json is written to temp/check_<goldfile> so
+//that it can be inspected and copied over the gold file if the change is expected
+func CheckGold(t *testing.T, goldfile, json string) {
+	t.Helper()
+	gold, err := os.ReadFile(RootPath(goldfile))
+	AssertSuccess(t, err)
+
+	goldLF := strings.ReplaceAll(string(gold), "\r", "")
+	jsonLF := strings.ReplaceAll(json, "\r", "")
+
+	if string(goldLF) != jsonLF {
+		err = os.MkdirAll(RootPath("temp"), 0755)
+		AssertSuccess(t, err)
+		err = os.WriteFile(TempPath("check_"+goldfile), []byte(json), 0644)
+		AssertSuccess(t, err)
+		t.Errorf("The generated file is different from the gold file %s", goldfile)
+	}
+}
+
+//RootPath returns the path of the named file in the root of the module, found by
+//walking up from the working directory to the directory containing go.mod
+func RootPath(name string) string {
+	dir, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return filepath.Join(dir, name)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			panic("go.mod not found above the working directory")
+		}
+		dir = parent
+	}
+}
+
+//TempPath returns the path of the named file in the temp directory of the module
+func TempPath(name string) string {
+	return RootPath(filepath.Join("temp", name))
+}
+
+//FileURL returns the file URL of the named file in the root of the module
+func FileURL(name string) string {
+	return fmt.Sprintf("file:///%s", strings.Replace(RootPath(name), "\\", "/", -1))
+}
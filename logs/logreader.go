@@ -0,0 +1,177 @@
+//Package logs reads the request logs that coverage is measured from. Each log
+//format has a LogReader that parses its lines into RequestLogEntry values, and the
+//entries can be normalised and rewritten before they are checked
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/codeafix/apicovchk/filereader"
+)
+
+//LogReader is used to read data from a URL into an array of log entries
+type LogReader interface {
+	SetLogURL(urlstring string) error
+	SetNormalisation(n Normalisation)
+	GetLogEntries() ([]RequestLogEntry, error)
+}
+
+//LogReaderInfo contains the URL the LogReader should read from, and the
+//normalisation to apply to the path of each URL in the log
+type LogReaderInfo struct {
+	URLReader     filereader.URLReader
+	Normalisation Normalisation
+}
+
+//SetNormalisation sets the normalisation the reader applies to the path of each
+//URL in the log
+func (lr *LogReaderInfo) SetNormalisation(n Normalisation) {
+	lr.Normalisation = n
+}
+
+//SetLogURL set's the URL to the log file that the reader should read
+func (lr *LogReaderInfo) SetLogURL(urlstring string) error {
+	ur, err := filereader.NewURLReader(urlstring)
+	if err != nil {
+		return err
+	}
+	lr.URLReader = ur
+	return nil
+}
+
+//RequestLogEntry contains one entry from the request log that is produced by an application test framework.
+//Any set of application tests that record the http requests in this format can be used to check the coverage of the
+//API as defined in an associated Swagger description.
+type RequestLogEntry struct {
+	Method       string      `json:"method"`
+	Path         string      `json:"path"`
+	PathElements []string    `json:"pathElements"`
+	URL          *url.URL    `json:"url"`
+	Query        url.Values  `json:"query"`
+	Form         url.Values  `json:"form,omitempty"`
+	Headers      http.Header `json:"headers,omitempty"`
+	JSONBody     interface{} `json:"-"`
+	ResponseBody interface{} `json:"-"`
+	Service      string      `json:"service"`
+	Response     string      `json:"response"`
+}
+
+//SetURL sets the URL of the log entry, and sets the service, path, and path elements
+//from the URL's path after normalising it. The service is the first element of the path
+func (le *RequestLogEntry) SetURL(u *url.URL, n Normalisation) error {
+	els := n.SplitPath(u)
+	if len(els) < 1 || els[0] == "" {
+		return fmt.Errorf("Expecting 1 or more elements in URL path '%s'", u.Path)
+	}
+	le.URL = u
+	le.Service = els[0]
+	le.PathElements = els[1:]
+	le.Path = "/" + strings.Join(els[1:], "/")
+	return nil
+}
+
+//ParseHeaders parses the request headers recorded in a log file column. Each header
+//is written as "Name: value" and multiple headers are separated by "|"
+func ParseHeaders(col string) http.Header {
+	h := http.Header{}
+	for _, hdr := range strings.Split(col, "|") {
+		i := strings.Index(hdr, ":")
+		if i <= 0 {
+			continue
+		}
+		h.Add(strings.TrimSpace(hdr[:i]), strings.TrimSpace(hdr[i+1:]))
+	}
+	return h
+}
+
+//ParseFormBody returns the form fields found in a logged request body. Both
+//application/x-www-form-urlencoded and multipart/form-data bodies are recognised,
+//a multipart body is identified by its first line being the boundary delimiter.
+//Bodies that are not form bodies (e.g. JSON, or "undefined") return nil
+func ParseFormBody(body string) url.Values {
+	body = unquoteBody(body)
+	if body == "" || body == "undefined" {
+		return nil
+	}
+	if strings.HasPrefix(body, "--") {
+		return parseMultipartBody(body)
+	}
+	if strings.HasPrefix(body, "{") || strings.HasPrefix(body, "[") || !strings.Contains(body, "=") {
+		return nil
+	}
+	form, err := url.ParseQuery(body)
+	if err != nil {
+		return nil
+	}
+	for key := range form {
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil
+		}
+	}
+	return form
+}
+
+//ParseJSONBody returns the decoded content of a logged request body that is a JSON
+//object or array. Any other body returns nil
+func ParseJSONBody(body string) interface{} {
+	body = unquoteBody(body)
+	if !strings.HasPrefix(body, "{") && !strings.HasPrefix(body, "[") {
+		return nil
+	}
+	var v interface{}
+	if json.Unmarshal([]byte(body), &v) != nil {
+		return nil
+	}
+	return v
+}
+
+//unquoteBody trims a logged body. Bodies containing delimiters are quoted in the
+//same way as a CSV field so the quotes are removed
+func unquoteBody(body string) string {
+	body = strings.TrimSpace(body)
+	if len(body) > 1 && strings.HasPrefix(body, `"`) && strings.HasSuffix(body, `"`) {
+		body = strings.ReplaceAll(body[1:len(body)-1], `""`, `"`)
+	}
+	return body
+}
+
+//parseMultipartBody reads the field names from a multipart body. Log files hold one
+//request per line so the line breaks within the body are expected to be escaped
+func parseMultipartBody(body string) url.Values {
+	body = strings.NewReplacer(`\r\n`, "\r\n", `\n`, "\r\n").Replace(body)
+	boundary := strings.TrimSpace(strings.SplitN(body, "\n", 2)[0])[2:]
+	if boundary == "" {
+		return nil
+	}
+	form := url.Values{}
+	mr := multipart.NewReader(strings.NewReader(body), boundary)
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+		name := part.FormName()
+		if name == "" {
+			continue
+		}
+		if part.FileName() != "" {
+			form.Add(name, part.FileName())
+			continue
+		}
+		val, err := io.ReadAll(part)
+		if err != nil {
+			break
+		}
+		form.Add(name, string(val))
+	}
+	if len(form) == 0 {
+		return nil
+	}
+	return form
+}
@@ -1,4 +1,4 @@
-package main
+package logs
 
 //LogReaderRepo is used to hold the list of available LogReaders
 type LogReaderRepo struct {
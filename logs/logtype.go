@@ -1,25 +1,10 @@
-package main
+package logs
 
 import (
 	"errors"
 	"strings"
 )
 
-//Config contains the list of transaction log files to read, and an entry for each
-//service that defines the reverse proxy path name for the service, and the
-//swagger.json file to use.
-type Config struct {
-	TransactionLogs []LogEntry     `json:"transactionLogFiles"`
-	Services        []ServiceEntry `json:"services"`
-}
-
-//ServiceEntry contains the path name used by the reverse proxy to route to the
-//service and the URI of the swagger.json file to use for that service.
-type ServiceEntry struct {
-	RoutePath string `json:"routePath"`
-	Swagger   string `json:"swagger"`
-}
-
 //LogEntry contains the path and type of a log file to read
 type LogEntry struct {
 	LogURL  string  `json:"logURL"`
@@ -0,0 +1,66 @@
+package logs
+
+import (
+	"net/url"
+	"strings"
+)
+
+//Normalisation contains the options used to normalise the path of each logged
+//request URL before it is checked against the API
+type Normalisation struct {
+	TrailingSlash    bool `json:"trailingSlash"`
+	DuplicateSlashes bool `json:"duplicateSlashes"`
+	PercentDecode    bool `json:"percentDecode"`
+	CaseInsensitive  bool `json:"caseInsensitive"`
+}
+
+//SplitPath splits the path of the passed URL into its elements applying the
+//normalisation options. The leading slash of the path is discarded
+//  - TrailingSlash removes the empty element left by a trailing slash
+//  - DuplicateSlashes removes the empty elements left by repeated slashes
+//  - PercentDecode splits the path before it is decoded, so an encoded slash
+//    (%2F) stays inside its element, and decodes any percent-encoding that
+//    remains in an element e.g. from double encoding
+//  - CaseInsensitive converts every element to lower case
+func (n Normalisation) SplitPath(u *url.URL) []string {
+	els := strings.Split(u.Path, "/")
+	if n.PercentDecode {
+		els = strings.Split(u.EscapedPath(), "/")
+		for i, el := range els {
+			els[i] = percentDecode(el)
+		}
+	}
+	if len(els) > 0 && els[0] == "" {
+		els = els[1:]
+	}
+	if n.DuplicateSlashes {
+		nels := []string{}
+		for i, el := range els {
+			if el != "" || i == len(els)-1 {
+				nels = append(nels, el)
+			}
+		}
+		els = nels
+	}
+	if n.TrailingSlash && len(els) > 1 && els[len(els)-1] == "" {
+		els = els[:len(els)-1]
+	}
+	if n.CaseInsensitive {
+		for i, el := range els {
+			els[i] = strings.ToLower(el)
+		}
+	}
+	return els
+}
+
+//percentDecode decodes a path element until no valid percent-encoding remains
+func percentDecode(el string) string {
+	for strings.Contains(el, "%") {
+		dec, err := url.PathUnescape(el)
+		if err != nil || dec == el {
+			break
+		}
+		el = dec
+	}
+	return el
+}
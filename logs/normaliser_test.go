@@ -0,0 +1,45 @@
+package logs
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+)
+
+func SplitTestPath(t *testing.T, n Normalisation, rawurl string) string {
+	u, err := url.Parse(rawurl)
+	AssertSuccess(t, err)
+	return strings.Join(n.SplitPath(u), ",")
+}
+
+func TestSplitPathWithoutNormalisation(t *testing.T) {
+	n := Normalisation{}
+	AreEqual(t, "petstore,pet,", SplitTestPath(t, n, "http://localhost/petstore/pet/"), "Trailing slash changed")
+	AreEqual(t, "petstore,,pet", SplitTestPath(t, n, "http://localhost/petstore//pet"), "Duplicate slash changed")
+	AreEqual(t, "petstore,Pet,a,b", SplitTestPath(t, n, "http://localhost/petstore/Pet/a%2Fb"), "Encoded path changed")
+}
+
+func TestSplitPathTrailingSlash(t *testing.T) {
+	n := Normalisation{TrailingSlash: true}
+	AreEqual(t, "petstore,pet", SplitTestPath(t, n, "http://localhost/petstore/pet/"), "Trailing slash not removed")
+	AreEqual(t, "auth", SplitTestPath(t, n, "http://localhost/auth/?role=User"), "Trailing slash not removed")
+}
+
+func TestSplitPathDuplicateSlashes(t *testing.T) {
+	n := Normalisation{DuplicateSlashes: true}
+	AreEqual(t, "petstore,pet,1", SplitTestPath(t, n, "http://localhost//petstore//pet///1"), "Duplicate slashes not removed")
+	AreEqual(t, "petstore,pet,", SplitTestPath(t, n, "http://localhost/petstore/pet//"), "Trailing slash removed")
+}
+
+func TestSplitPathPercentDecode(t *testing.T) {
+	n := Normalisation{PercentDecode: true}
+	AreEqual(t, "petstore,{id},a/b", SplitTestPath(t, n, "http://localhost/petstore/%7Bid%7D/a%2Fb"), "Path not decoded")
+	AreEqual(t, "petstore,{id}", SplitTestPath(t, n, "http://localhost/petstore/%257Bid%257D"), "Double encoded path not decoded")
+}
+
+func TestSplitPathCaseInsensitive(t *testing.T) {
+	n := Normalisation{CaseInsensitive: true}
+	AreEqual(t, "petstore,pet,findbystatus", SplitTestPath(t, n, "http://localhost/PetStore/Pet/findByStatus"), "Path not lower case")
+}
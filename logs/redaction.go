@@ -0,0 +1,73 @@
+package logs
+
+import (
+	"net/http"
+	"net/url"
+)
+
+//RedactedValue replaces the value of each credential in a recorded request
+const RedactedValue = "<redacted>"
+
+//CredentialHeaders are the request headers that carry credentials for any API
+var CredentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+//Redaction lists the request headers and query parameters whose values are
+//credentials, which must not be written to a log
+type Redaction struct {
+	Headers []string
+	Query   []string
+}
+
+//NewRedaction returns a Redaction of the CredentialHeaders
+func NewRedaction() Redaction {
+	return Redaction{
+		Headers: append([]string{}, CredentialHeaders...),
+		Query:   []string{},
+	}
+}
+
+//Redact replaces the values of the redacted headers and query parameters of the
+//log entry with RedactedValue. The headers, query and URL of the entry are copied
+//before they are changed. Only the presence of a credential is used to check the
+//security of an operation, so redacted entries are covered in the same way
+func (r Redaction) Redact(le *RequestLogEntry) {
+	if le.Headers != nil {
+		h := le.Headers.Clone()
+		for _, name := range r.Headers {
+			key := http.CanonicalHeaderKey(name)
+			if vals, exists := h[key]; exists {
+				h[key] = redactValues(vals)
+			}
+		}
+		le.Headers = h
+	}
+	if le.Query == nil {
+		return
+	}
+	q := url.Values{}
+	redacted := false
+	for key, vals := range le.Query {
+		q[key] = vals
+	}
+	for _, name := range r.Query {
+		if vals, exists := q[name]; exists {
+			q[name] = redactValues(vals)
+			redacted = true
+		}
+	}
+	le.Query = q
+	if redacted && le.URL != nil {
+		u := *le.URL
+		u.RawQuery = q.Encode()
+		le.URL = &u
+	}
+}
+
+//redactValues returns a RedactedValue for each of the values
+func redactValues(vals []string) []string {
+	redacted := make([]string, len(vals))
+	for i := range vals {
+		redacted[i] = RedactedValue
+	}
+	return redacted
+}
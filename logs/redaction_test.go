@@ -0,0 +1,34 @@
+package logs
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+)
+
+func TestRedact(t *testing.T) {
+	u, err := url.Parse("http://localhost/petstore/pet?api_key=s3cr3t&status=sold")
+	AssertSuccess(t, err)
+	le := RequestLogEntry{Method: "GET", Response: "200", Query: u.Query(),
+		Headers: http.Header{"Authorization": {"Bearer s3cr3t"}, "Cookie": {"session=s3cr3t"}, "X-Api-Key": {"s3cr3t"}, "Accept": {"application/json"}}}
+	err = le.SetURL(u, Normalisation{})
+	AssertSuccess(t, err)
+	orig := le
+	r := NewRedaction()
+	r.Headers = append(r.Headers, "x-api-key")
+	r.Query = append(r.Query, "api_key")
+	r.Redact(&le)
+	AreEqual(t, RedactedValue, le.Headers.Get("Authorization"), "Authorization not redacted")
+	AreEqual(t, RedactedValue, le.Headers.Get("Cookie"), "Cookie not redacted")
+	AreEqual(t, RedactedValue, le.Headers.Get("X-Api-Key"), "apiKey header not redacted")
+	AreEqual(t, "application/json", le.Headers.Get("Accept"), "Other header redacted")
+	AreEqual(t, RedactedValue, le.Query.Get("api_key"), "apiKey query parameter not redacted")
+	AreEqual(t, "sold", le.Query.Get("status"), "Other query parameter redacted")
+	IsFalse(t, strings.Contains(le.URL.String(), "s3cr3t"), "apiKey not redacted from the URL")
+	AreEqual(t, "Bearer s3cr3t", orig.Headers.Get("Authorization"), "Original headers changed")
+	AreEqual(t, "s3cr3t", orig.Query.Get("api_key"), "Original query changed")
+	IsTrue(t, strings.Contains(orig.URL.String(), "s3cr3t"), "Original URL changed")
+}
@@ -0,0 +1,57 @@
+package logs
+
+import (
+	"fmt"
+	"regexp"
+)
+
+//RewriteRule is a regular expression that is replaced in the path of each logged
+//request URL before the request is checked against the API. The replacement can
+//refer to capture groups in the expression e.g. "$1"
+type RewriteRule struct {
+	Match   string `json:"match"`
+	Replace string `json:"replace"`
+}
+
+//Rewriter applies a list of rewrite rules, in order, to log entries. The rewritten
+//path is normalised in the same way as the log readers normalise paths
+type Rewriter struct {
+	Rules         []RewriteRule
+	Regexps       []*regexp.Regexp
+	Normalisation Normalisation
+}
+
+//NewRewriter compiles the passed rewrite rules and returns a new Rewriter
+func NewRewriter(rules []RewriteRule) (*Rewriter, error) {
+	rw := &Rewriter{
+		Rules:   rules,
+		Regexps: []*regexp.Regexp{},
+	}
+	for _, rule := range rules {
+		r, err := regexp.Compile(rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid rewrite rule '%s': %s", rule.Match, err.Error())
+		}
+		rw.Regexps = append(rw.Regexps, r)
+	}
+	return rw, nil
+}
+
+//Rewrite applies the rewrite rules to the URL path of the passed log entry, and
+//updates the entry's URL, service, path, and path elements from the rewritten path
+func (rw *Rewriter) Rewrite(le *RequestLogEntry) error {
+	if le.URL == nil || len(rw.Regexps) == 0 {
+		return nil
+	}
+	path := le.URL.Path
+	for i, r := range rw.Regexps {
+		path = r.ReplaceAllString(path, rw.Rules[i].Replace)
+	}
+	if path == le.URL.Path {
+		return nil
+	}
+	u := *le.URL
+	u.Path = path
+	u.RawPath = ""
+	return le.SetURL(&u, rw.Normalisation)
+}
@@ -0,0 +1,41 @@
+package logs
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+)
+
+func TestNewRewriterFailsWithInvalidRule(t *testing.T) {
+	_, err := NewRewriter([]RewriteRule{{Match: "(", Replace: ""}})
+	IsTrue(t, err != nil, "Invalid rewrite rule did not fail")
+}
+
+func TestRewrite(t *testing.T) {
+	rw, err := NewRewriter([]RewriteRule{
+		{Match: "^/internal", Replace: ""},
+		{Match: "^/v1/pets(/|$)", Replace: "/petstore/pet$1"},
+	})
+	AssertSuccess(t, err)
+	u, err := url.Parse("https://127.0.0.1:8081/internal/v1/pets/12?status=sold")
+	AssertSuccess(t, err)
+	le := RequestLogEntry{URL: u, Service: "internal"}
+	err = rw.Rewrite(&le)
+	AssertSuccess(t, err)
+	AreEqual(t, "petstore", le.Service, "Service not correct")
+	AreEqual(t, "/pet/12", le.Path, "Path not correct")
+	AreEqual(t, 2, len(le.PathElements), "Wrong number of path elements")
+	AreEqual(t, "https://127.0.0.1:8081/petstore/pet/12?status=sold", le.URL.String(), "URL not correct")
+	AreEqual(t, "https://127.0.0.1:8081/internal/v1/pets/12?status=sold", u.String(), "Original URL changed")
+}
+
+func TestRewriteFailsWhenPathRemoved(t *testing.T) {
+	rw, err := NewRewriter([]RewriteRule{{Match: ".*", Replace: ""}})
+	AssertSuccess(t, err)
+	u, err := url.Parse("https://127.0.0.1:8081/petstore/pet")
+	AssertSuccess(t, err)
+	le := RequestLogEntry{URL: u}
+	err = rw.Rewrite(&le)
+	IsTrue(t, err != nil, "Rewrite to empty path did not fail")
+}
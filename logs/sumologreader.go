@@ -1,4 +1,4 @@
-package main
+package logs
 
 import (
 	"bufio"
@@ -8,8 +8,12 @@ import (
 	"strings"
 )
 
+//MaskedValue is the value that Sumo exports put in place of the IDs in a path and
+//the values of the query parameters, so that only their presence is known
+const MaskedValue = "*"
+
 //ParseSumoLogEntry creates a new RequestLogEntry from a line in the sumo log file
-func (*SumoLogReaderInfo) ParseSumoLogEntry(logLine string) (RequestLogEntry, error) {
+func (slr *SumoLogReaderInfo) ParseSumoLogEntry(logLine string) (RequestLogEntry, error) {
 	rle := RequestLogEntry{}
 	vals := strings.Split(logLine, ",")
 	if len(vals) != 2 {
@@ -23,20 +27,12 @@ func (*SumoLogReaderInfo) ParseSumoLogEntry(logLine string) (RequestLogEntry, er
 	if err != nil {
 		return rle, err
 	}
-	els := strings.Split(url.Path, "/")
-	if els[0] == "" {
-		els = els[1:]
-	}
-	if len(els) < 1 || els[0] == "" {
-		return rle, fmt.Errorf("Expecting 1 or more elements in URL path '%s'", url.Path)
+	err = rle.SetURL(url, slr.Normalisation)
+	if err != nil {
+		return rle, err
 	}
-	path := "/" + strings.Join(els[1:], "/")
 	rle.Method = strings.ToUpper(strings.TrimSpace(vals[0][0:i]))
-	rle.Path = path
-	rle.PathElements = els[1:]
-	rle.URL = url
 	rle.Query = url.Query()
-	rle.Service = els[0]
 	rle.Response = strings.TrimSpace(vals[1])
 	return rle, nil
 }
@@ -1,11 +1,11 @@
-package main
+package logs
 
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
 )
 
 func TestParseRequestLogEntry(t *testing.T) {
@@ -101,11 +101,9 @@ func TestParseRequestLogEntryQuery(t *testing.T) {
 }
 
 func TestReadExampleSumoLog(t *testing.T) {
-	dir, err := os.Getwd()
-	filepath := fmt.Sprintf("file:///%s/sumologic.csv", strings.Replace(dir, "\\", "/", -1))
-
+	filepath := FileURL("sumologic.csv")
 	lr := NewSumoLogReader()
-	err = lr.SetLogURL(filepath)
+	err := lr.SetLogURL(filepath)
 	AssertSuccess(t, err)
 	lel, err := lr.GetLogEntries()
 	AssertSuccess(t, err)
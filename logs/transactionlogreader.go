@@ -1,4 +1,4 @@
-package main
+package logs
 
 import (
 	"bufio"
@@ -16,6 +16,8 @@ const methodpos = 3
 const urlpos = 4
 const bodypos = 5
 const responsepos = 6
+const headerspos = 7
+const responsebodypos = 8
 
 //TransactionLogInfo contains the URLReader the LogReader should read from
 type TransactionLogInfo struct {
@@ -34,7 +36,7 @@ type TransactionLogEntry struct {
 }
 
 //ParseTransactionLogEntry creates a new TransactionLogEntry from a line in the TransactionLog file
-func (*TransactionLogInfo) ParseTransactionLogEntry(logLine string) (TransactionLogEntry, error) {
+func (lr *TransactionLogInfo) ParseTransactionLogEntry(logLine string) (TransactionLogEntry, error) {
 	tle := TransactionLogEntry{}
 	vals := strings.Split(logLine, "\t")
 	if len(vals) <= responsepos {
@@ -48,25 +50,25 @@ func (*TransactionLogInfo) ParseTransactionLogEntry(logLine string) (Transaction
 	if err != nil {
 		return tle, err
 	}
-	els := strings.Split(url.Path, "/")
-	if els[0] == "" {
-		els = els[1:]
-	}
-	if len(els) < 1 || els[0] == "" {
-		return tle, fmt.Errorf("Expecting 1 or more elements in URL path '%s'", url.Path)
+	err = tle.SetURL(url, lr.Normalisation)
+	if err != nil {
+		return tle, err
 	}
-	path := "/" + strings.Join(els[1:], "/")
 	tle.Duration = dur
 	tle.Start = strings.TrimSpace(vals[startpos])
 	tle.End = strings.TrimSpace(vals[endpos])
 	tle.Method = strings.ToUpper(strings.TrimSpace(vals[methodpos]))
-	tle.URL = url
-	tle.Path = path
-	tle.PathElements = els[1:]
 	tle.Query = url.Query()
-	tle.Service = els[0]
 	tle.Body = strings.TrimSpace(vals[bodypos])
+	tle.Form = ParseFormBody(tle.Body)
+	tle.JSONBody = ParseJSONBody(tle.Body)
 	tle.Response = strings.TrimSpace(vals[responsepos])
+	if len(vals) > headerspos {
+		tle.Headers = ParseHeaders(vals[headerspos])
+	}
+	if len(vals) > responsebodypos {
+		tle.ResponseBody = ParseJSONBody(vals[responsebodypos])
+	}
 	return tle, nil
 }
 
@@ -1,11 +1,10 @@
-package main
+package logs
 
 import (
 	"encoding/json"
-	"fmt"
-	"os"
-	"strings"
 	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
 )
 
 func TestParseTransactionLogEntry(t *testing.T) {
@@ -104,11 +103,10 @@ func TestParseTransactionLogEntryQuery(t *testing.T) {
 }
 
 func TestReadExampleLog(t *testing.T) {
-	dir, err := os.Getwd()
-	filepath := fmt.Sprintf("file:///%s/coverage-report.txt", strings.Replace(dir, "\\", "/", -1))
+	filepath := FileURL("coverage-report.txt")
 
 	lr := NewTransactionLogReader()
-	err = lr.SetLogURL(filepath)
+	err := lr.SetLogURL(filepath)
 	AssertSuccess(t, err)
 	lel, err := lr.GetLogEntries()
 	AssertSuccess(t, err)
@@ -116,3 +114,53 @@ func TestReadExampleLog(t *testing.T) {
 	AssertSuccess(t, err)
 	CheckGold(t, "LogEntriesFromLogReader.json", string(b))
 }
+
+func TestParseTransactionLogEntryFormBody(t *testing.T) {
+	ll := "663	18:55.0	18:55.6	POST	http://127.0.0.1:58800/petstore/pet/1	name=doggie&status=sold	200"
+	tlr := &TransactionLogInfo{}
+	tle, err := tlr.ParseTransactionLogEntry(ll)
+	AssertSuccess(t, err)
+	AreEqual(t, 2, len(tle.Form), "Wrong number of form parameters")
+	AreEqual(t, "doggie", tle.Form.Get("name"), "name not form parameter value")
+	AreEqual(t, "sold", tle.Form.Get("status"), "status not form parameter value")
+}
+
+func TestParseTransactionLogEntryMultipartBody(t *testing.T) {
+	ll := "663	18:55.0	18:55.6	POST	http://127.0.0.1:58800/petstore/pet/1/uploadImage	--XyZ\\r\\nContent-Disposition: form-data; name=\"additionalMetadata\"\\r\\n\\r\\nsome data\\r\\n--XyZ\\r\\nContent-Disposition: form-data; name=\"file\"; filename=\"dog.png\"\\r\\n\\r\\nPNG\\r\\n--XyZ--	200"
+	tlr := &TransactionLogInfo{}
+	tle, err := tlr.ParseTransactionLogEntry(ll)
+	AssertSuccess(t, err)
+	AreEqual(t, 2, len(tle.Form), "Wrong number of form parameters")
+	AreEqual(t, "some data", tle.Form.Get("additionalMetadata"), "additionalMetadata not form parameter value")
+	AreEqual(t, "dog.png", tle.Form.Get("file"), "file not form parameter value")
+}
+
+func TestParseTransactionLogEntryJSONBodyHasNoForm(t *testing.T) {
+	ll := "1828	18:57.8	18:59.7	POST	http://127.0.0.1:58800/orchestration/client	\"{\"\"startDate\"\":\"\"2016-12-31\"\"}\"	201"
+	tlr := &TransactionLogInfo{}
+	tle, err := tlr.ParseTransactionLogEntry(ll)
+	AssertSuccess(t, err)
+	IsTrue(t, tle.Form == nil, "JSON body parsed as form parameters")
+	body, ok := tle.JSONBody.(map[string]interface{})
+	IsTrue(t, ok, "JSON body not parsed")
+	AreEqual(t, "2016-12-31", body["startDate"], "Wrong JSON body content")
+}
+
+func TestParseTransactionLogEntryHeaders(t *testing.T) {
+	ll := "663	18:55.0	18:55.6	GET	http://127.0.0.1:58800/petstore/pet/1	undefined	200	Authorization: Bearer abc.def | api_key: special-key"
+	tlr := &TransactionLogInfo{}
+	tle, err := tlr.ParseTransactionLogEntry(ll)
+	AssertSuccess(t, err)
+	AreEqual(t, "Bearer abc.def", tle.Headers.Get("Authorization"), "Authorization header not correct")
+	AreEqual(t, "special-key", tle.Headers.Get("api_key"), "api_key header not correct")
+}
+
+func TestParseTransactionLogEntryResponseBody(t *testing.T) {
+	ll := "663	18:55.0	18:55.6	GET	http://127.0.0.1:58800/petstore/pet/1	undefined	200	Accept: application/json	{\"id\":1,\"name\":\"rex\"}"
+	tlr := &TransactionLogInfo{}
+	tle, err := tlr.ParseTransactionLogEntry(ll)
+	AssertSuccess(t, err)
+	body, ok := tle.ResponseBody.(map[string]interface{})
+	IsTrue(t, ok, "Response body not parsed")
+	AreEqual(t, "rex", body["name"], "Wrong response body content")
+}
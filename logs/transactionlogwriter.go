@@ -0,0 +1,81 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//TransactionLogHeader is the first line of a transaction log, naming its columns
+const TransactionLogHeader = "duration(ms)\tstart-time\tend-time\tmethod\turl\tbody\tresponse\theaders\tresponse-body\n"
+
+//WriteTransactionLog writes the passed entries to w in the transaction log format,
+//so that they can be read back by a TransactionLogReader
+func WriteTransactionLog(w io.Writer, entries []TransactionLogEntry) error {
+	_, err := io.WriteString(w, TransactionLogHeader)
+	if err != nil {
+		return err
+	}
+	for _, tle := range entries {
+		_, err = io.WriteString(w, FormatTransactionLogEntry(tle))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//FormatTransactionLogEntry returns the line of a transaction log that records the
+//passed entry, including the line break. The values of the CredentialHeaders are
+//always redacted
+func FormatTransactionLogEntry(tle TransactionLogEntry) string {
+	NewRedaction().Redact(&tle.RequestLogEntry)
+	u := ""
+	if tle.URL != nil {
+		u = tle.URL.String()
+	}
+	resp := ""
+	if tle.ResponseBody != nil {
+		b, err := json.Marshal(tle.ResponseBody)
+		if err == nil {
+			resp = string(b)
+		}
+	}
+	return fmt.Sprintf("%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", tle.Duration, tle.Start, tle.End, tle.Method, u,
+		EscapeBody(tle.Body), tle.Response, FormatHeaders(tle.Headers), resp)
+}
+
+//FormatHeaders writes the headers as "Name: value" pairs separated by "|", in the
+//form read by ParseHeaders. The headers are sorted by name
+func FormatHeaders(h http.Header) string {
+	names := []string{}
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	hdrs := []string{}
+	for _, name := range names {
+		for _, val := range h[name] {
+			hdrs = append(hdrs, fmt.Sprintf("%s: %s", name, val))
+		}
+	}
+	return strings.Join(hdrs, "|")
+}
+
+//EscapeBody returns the body in a form that fits in one column of a log file. JSON
+//bodies are compacted, and other line breaks are escaped as "\r\n" in the way
+//multipart bodies are expected to be logged. An empty body is logged as "undefined"
+func EscapeBody(body string) string {
+	if body == "" {
+		return "undefined"
+	}
+	var buf bytes.Buffer
+	if json.Compact(&buf, []byte(body)) == nil {
+		body = buf.String()
+	}
+	return strings.NewReplacer("\r\n", `\r\n`, "\n", `\r\n`, "\t", " ").Replace(body)
+}
@@ -0,0 +1,72 @@
+package logs
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+)
+
+func TestFormatHeaders(t *testing.T) {
+	h := http.Header{"Content-Type": {"application/json"}, "Authorization": {"Bearer abc"}}
+	AreEqual(t, "Authorization: Bearer abc|Content-Type: application/json", FormatHeaders(h), "Headers not formatted")
+	AreEqual(t, "", FormatHeaders(nil), "Nil headers not empty")
+}
+
+func TestEscapeBody(t *testing.T) {
+	AreEqual(t, "undefined", EscapeBody(""), "Empty body not undefined")
+	AreEqual(t, `{"name":"rex"}`, EscapeBody("{\n\t\"name\": \"rex\"\n}"), "JSON body not compacted")
+	AreEqual(t, `--b\r\nContent-Disposition: form-data; name="a"\r\n\r\n1\r\n--b--`,
+		EscapeBody("--b\r\nContent-Disposition: form-data; name=\"a\"\r\n\r\n1\r\n--b--"), "Line breaks not escaped")
+}
+
+func TestWriteTransactionLogCanBeReadBack(t *testing.T) {
+	u, err := url.Parse("http://localhost:8080/petstore/pet/findByStatus?status=sold")
+	AssertSuccess(t, err)
+	tle := TransactionLogEntry{Duration: 12, Start: "10:00:00.000", End: "10:00:00.012", Body: "name=rex&status=sold"}
+	tle.Method = "POST"
+	tle.Response = "200"
+	tle.Headers = http.Header{"Authorization": {"Bearer abc"}}
+	tle.ResponseBody = []interface{}{map[string]interface{}{"name": "rex"}}
+	err = tle.SetURL(u, Normalisation{})
+	AssertSuccess(t, err)
+
+	var buf bytes.Buffer
+	err = WriteTransactionLog(&buf, []TransactionLogEntry{tle})
+	AssertSuccess(t, err)
+	lines := strings.Split(buf.String(), "\n")
+	AreEqual(t, 3, len(lines), "Wrong number of lines")
+	AreEqual(t, strings.TrimSuffix(TransactionLogHeader, "\n"), lines[0], "Header not written")
+
+	tlr := &TransactionLogInfo{}
+	read, err := tlr.ParseTransactionLogEntry(lines[1])
+	AssertSuccess(t, err)
+	AreEqual(t, 12, read.Duration, "Duration not correct")
+	AreEqual(t, "POST", read.Method, "Method not correct")
+	AreEqual(t, u.String(), read.URL.String(), "URL not correct")
+	AreEqual(t, "sold", read.Query.Get("status"), "Query not correct")
+	AreEqual(t, "rex", read.Form.Get("name"), "Form not correct")
+	AreEqual(t, "200", read.Response, "Response not correct")
+	AreEqual(t, RedactedValue, read.Headers.Get("Authorization"), "Headers not correct")
+	body, ok := read.ResponseBody.([]interface{})
+	IsTrue(t, ok && len(body) == 1, "Response body not correct")
+}
+
+func TestFormatTransactionLogEntryRedactsCredentials(t *testing.T) {
+	u, err := url.Parse("http://localhost:8080/petstore/pet")
+	AssertSuccess(t, err)
+	tle := TransactionLogEntry{}
+	tle.Method = "GET"
+	tle.Response = "200"
+	tle.Headers = http.Header{"Authorization": {"Bearer s3cr3t-token"}, "Cookie": {"session=abc"}, "Proxy-Authorization": {"Basic s3cr3t"}}
+	err = tle.SetURL(u, Normalisation{})
+	AssertSuccess(t, err)
+	line := FormatTransactionLogEntry(tle)
+	IsFalse(t, strings.Contains(line, "s3cr3t") || strings.Contains(line, "session=abc"), "Credentials written to the log")
+	IsTrue(t, strings.Contains(line, "Authorization: "+RedactedValue), "Authorization header not written")
+	AreEqual(t, "Bearer s3cr3t-token", tle.Headers.Get("Authorization"), "Entry changed by formatting")
+}
+
@@ -0,0 +1,19 @@
+package pathmap
+
+//Response classes used to break down the response coverage of an operation
+const (
+	//SuccessClass is the class of 2xx response codes
+	SuccessClass = "2xx"
+	//ClientErrorClass is the class of 4xx response codes
+	ClientErrorClass = "4xx"
+	//ServerErrorClass is the class of 5xx response codes
+	ServerErrorClass = "5xx"
+)
+
+//ResponseClass returns the class of the passed response code e.g. "4xx" for "404"
+func ResponseClass(code string) string {
+	if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return ""
+	}
+	return code[0:1] + "xx"
+}
@@ -0,0 +1,331 @@
+package pathmap
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/go-openapi/spec"
+)
+
+//Contract holds the parts of an operation's Swagger description that logged
+//requests and responses are validated against
+type Contract struct {
+	PathTemplate []string
+	Parameters   []spec.Parameter
+	Responses    map[string]*spec.Schema
+	Definitions  spec.Definitions
+}
+
+//NewContract returns the contract of the operation on the Swagger path. The
+//parameters of the path are included unless the operation overrides them, and
+//references to shared parameters and responses are resolved
+func NewContract(path string, spi spec.PathItem, op *spec.Operation, swgr *spec.Swagger) *Contract {
+	c := &Contract{
+		PathTemplate: strings.Split(strings.TrimPrefix(path, "/"), "/"),
+		Responses:    map[string]*spec.Schema{},
+		Definitions:  swgr.Definitions,
+	}
+	params := map[string]int{}
+	for _, p := range append(append([]spec.Parameter{}, spi.Parameters...), op.Parameters...) {
+		p = resolveParameter(p, swgr)
+		key := p.In + ":" + p.Name
+		if i, exists := params[key]; exists {
+			c.Parameters[i] = p
+			continue
+		}
+		params[key] = len(c.Parameters)
+		c.Parameters = append(c.Parameters, p)
+	}
+	if op.Responses == nil {
+		return c
+	}
+	for code, resp := range op.Responses.StatusCodeResponses {
+		resp = resolveResponse(resp, swgr)
+		if resp.Schema != nil {
+			c.Responses[strconv.Itoa(code)] = resp.Schema
+		}
+	}
+	if op.Responses.Default != nil {
+		resp := resolveResponse(*op.Responses.Default, swgr)
+		if resp.Schema != nil {
+			c.Responses[DefaultResponseKey] = resp.Schema
+		}
+	}
+	return c
+}
+
+//resolveParameter returns the shared parameter a parameter refers to
+func resolveParameter(p spec.Parameter, swgr *spec.Swagger) spec.Parameter {
+	ref := p.Ref.String()
+	if ref == "" {
+		return p
+	}
+	if shared, exists := swgr.Parameters[strings.TrimPrefix(ref, "#/parameters/")]; exists {
+		return shared
+	}
+	return p
+}
+
+//resolveResponse returns the shared response a response refers to
+func resolveResponse(r spec.Response, swgr *spec.Swagger) spec.Response {
+	ref := r.Ref.String()
+	if ref == "" {
+		return r
+	}
+	if shared, exists := swgr.Responses[strings.TrimPrefix(ref, "#/responses/")]; exists {
+		return shared
+	}
+	return r
+}
+
+//Validate checks the logged request against the contract and returns a message
+//for each way the request broke it. The path, query, header and formData parameters
+//are checked for presence and type, a logged JSON body is checked against the
+//body schema, and a logged JSON response body against the schema of the
+//documented response it matched. Headers and form fields are only checked when
+//they were logged, and masked values (e.g. from a Sumo log) only for presence
+func (c *Contract) Validate(le logs.RequestLogEntry, response string) []string {
+	msgs := []string{}
+	for _, p := range c.Parameters {
+		var vals []string
+		logged := true
+		switch p.In {
+		case "path":
+			vals = c.pathValues(le.PathElements, p.Name)
+		case "query":
+			vals = le.Query[p.Name]
+		case "header":
+			vals = le.Headers.Values(p.Name)
+			logged = le.Headers != nil
+		case "formData":
+			vals = le.Form[p.Name]
+			logged = le.Form != nil
+		case "body":
+			if p.Schema != nil && le.JSONBody != nil {
+				msgs = append(msgs, ValidateSchema(le.JSONBody, p.Schema, c.Definitions, "body")...)
+			}
+			continue
+		default:
+			continue
+		}
+		if !logged {
+			continue
+		}
+		name := fmt.Sprintf("%s parameter '%s'", p.In, p.Name)
+		if len(vals) == 0 {
+			if p.Required {
+				msgs = append(msgs, fmt.Sprintf("missing required %s", name))
+			}
+			continue
+		}
+		if p.Type == "file" {
+			continue
+		}
+		for _, val := range vals {
+			if val == logs.MaskedValue {
+				continue
+			}
+			if msg := validateParameterValue(val, p); msg != "" {
+				msgs = append(msgs, fmt.Sprintf("%s %s", name, msg))
+			}
+		}
+	}
+	if schema, exists := c.Responses[response]; exists && le.ResponseBody != nil {
+		where := fmt.Sprintf("response %s body", response)
+		msgs = append(msgs, ValidateSchema(le.ResponseBody, schema, c.Definitions, where)...)
+	}
+	return msgs
+}
+
+//pathValues returns the value of the named path parameter from the logged path
+//elements
+func (c *Contract) pathValues(elements []string, name string) []string {
+	if len(elements) != len(c.PathTemplate) {
+		return nil
+	}
+	for i, el := range c.PathTemplate {
+		if el == "{"+name+"}" {
+			return []string{elements[i]}
+		}
+	}
+	return nil
+}
+
+//validateParameterValue returns a message if the value does not satisfy the
+//parameter's type, or "" if it does. Array values are split using the parameter's
+//collectionFormat and each item is checked
+func validateParameterValue(val string, p spec.Parameter) string {
+	if p.Type != "array" {
+		return validateSimpleValue(val, &p.CommonValidations, p.Type, p.Format)
+	}
+	if p.Items == nil {
+		return ""
+	}
+	sep := ","
+	switch p.CollectionFormat {
+	case "ssv":
+		sep = " "
+	case "tsv":
+		sep = "\t"
+	case "pipes":
+		sep = "|"
+	case "multi":
+		sep = ""
+	}
+	items := []string{val}
+	if sep != "" {
+		items = strings.Split(val, sep)
+	}
+	for _, item := range items {
+		if msg := validateSimpleValue(item, &p.Items.CommonValidations, p.Items.Type, p.Items.Format); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+//validateSimpleValue returns a message if the string value is not of the type,
+//or does not satisfy the enum, pattern, minimum or maximum, or "" if it does
+func validateSimpleValue(val string, cv *spec.CommonValidations, typ, format string) string {
+	switch typ {
+	case "integer", "number":
+		n, err := strconv.ParseFloat(val, 64)
+		if err != nil || (typ == "integer" && strings.ContainsAny(val, ".eE")) {
+			return fmt.Sprintf("is not a valid %s", typ)
+		}
+		if cv.Minimum != nil && n < *cv.Minimum {
+			return fmt.Sprintf("is less than the minimum %v", *cv.Minimum)
+		}
+		if cv.Maximum != nil && n > *cv.Maximum {
+			return fmt.Sprintf("is greater than the maximum %v", *cv.Maximum)
+		}
+	case "boolean":
+		if val != "true" && val != "false" {
+			return "is not a valid boolean"
+		}
+	}
+	if len(cv.Enum) > 0 {
+		found := false
+		for _, e := range cv.Enum {
+			if fmt.Sprint(e) == val {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "is not one of the allowed values"
+		}
+	}
+	if cv.Pattern != "" {
+		if matched, err := regexp.MatchString(cv.Pattern, val); err == nil && !matched {
+			return fmt.Sprintf("does not match the pattern '%s'", cv.Pattern)
+		}
+	}
+	return ""
+}
+
+//ValidateSchema checks the decoded JSON value against the schema and returns a
+//message for each way it does not satisfy it. References to definitions, allOf,
+//type, enum, required and properties, additionalProperties false, and array items
+//are checked. The messages name the location of the value starting at where
+func ValidateSchema(v interface{}, s *spec.Schema, defs spec.Definitions, where string) []string {
+	if ref := s.Ref.String(); ref != "" {
+		def, exists := defs[strings.TrimPrefix(ref, "#/definitions/")]
+		if !exists {
+			return nil
+		}
+		s = &def
+	}
+	msgs := []string{}
+	for i := range s.AllOf {
+		msgs = append(msgs, ValidateSchema(v, &s.AllOf[i], defs, where)...)
+	}
+	if v == nil {
+		nullable, _ := s.Extensions.GetBool("x-nullable")
+		if len(s.Type) > 0 && !s.Nullable && !nullable {
+			msgs = append(msgs, fmt.Sprintf("%s is null", where))
+		}
+		return msgs
+	}
+	found := jsonType(v)
+	if len(s.Type) > 0 && !s.Type.Contains(found) && !(found == "integer" && s.Type.Contains("number")) {
+		return append(msgs, fmt.Sprintf("%s should be %s but is %s", where, strings.Join(s.Type, " or "), found))
+	}
+	if len(s.Enum) > 0 {
+		allowed := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(v) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			msgs = append(msgs, fmt.Sprintf("%s is not one of the allowed values", where))
+		}
+	}
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for _, key := range s.Required {
+			if _, exists := val[key]; !exists {
+				msgs = append(msgs, fmt.Sprintf("%s is missing required property '%s'", where, key))
+			}
+		}
+		keys := []string{}
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			prop, exists := s.Properties[key]
+			if exists {
+				msgs = append(msgs, ValidateSchema(val[key], &prop, defs, where+"."+key)...)
+			} else if s.AdditionalProperties != nil && !s.AdditionalProperties.Allows {
+				msgs = append(msgs, fmt.Sprintf("%s has unexpected property '%s'", where, key))
+			}
+		}
+	case []interface{}:
+		if s.Items != nil && s.Items.Schema != nil {
+			for _, item := range val {
+				msgs = append(msgs, ValidateSchema(item, s.Items.Schema, defs, where+"[]")...)
+			}
+		}
+	}
+	return msgs
+}
+
+//jsonType returns the JSON schema type of the decoded JSON value
+func jsonType(v interface{}) string {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if val == float64(int64(val)) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "null"
+}
+
+//RecordViolations counts each of the messages against the verb
+func (v *Verb) RecordViolations(msgs []string) {
+	if len(msgs) == 0 {
+		return
+	}
+	if v.Violations == nil {
+		v.Violations = map[string]int{}
+	}
+	for _, msg := range msgs {
+		v.Violations[msg]++
+	}
+}
@@ -0,0 +1,110 @@
+package pathmap
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func NewTestContractPathMap(t *testing.T) *PathMap {
+	c := `{"swagger":"2.0",
+	"parameters":{"limit":{"name":"limit","in":"query","type":"integer","maximum":100}},
+	"definitions":{
+		"Pet":{"type":"object","required":["name"],"properties":{
+			"name":{"type":"string"},
+			"status":{"type":"string","enum":["available","sold"]},
+			"tags":{"type":"array","items":{"$ref":"#/definitions/Tag"}}}},
+		"Tag":{"type":"object","properties":{"id":{"type":"integer"}}}
+	},
+	"paths":{
+		"/pet/{petId}":{
+			"parameters":[{"name":"petId","in":"path","required":true,"type":"integer"}],
+			"get":{"parameters":[{"name":"X-Trace","in":"header","required":true,"type":"string"}],
+				"responses":{"200":{"description":"ok","schema":{"$ref":"#/definitions/Pet"}}}},
+			"put":{"parameters":[{"name":"body","in":"body","schema":{"$ref":"#/definitions/Pet"}}],
+				"responses":{"200":{"description":"ok"}}}
+		},
+		"/pet":{"get":{"parameters":[
+			{"$ref":"#/parameters/limit"},
+			{"name":"status","in":"query","required":true,"type":"array","items":{"type":"string","enum":["available","sold"]}}],
+			"responses":{"200":{"description":"ok"}}}}
+	}}`
+	pm := NewPathMap()
+	MapSwagger(t, pm, "petstore", c)
+	return pm
+}
+
+func violations(v *Verb) string {
+	msgs := []string{}
+	for msg, count := range v.Violations {
+		msgs = append(msgs, fmt.Sprintf("%s x%d", msg, count))
+	}
+	sort.Strings(msgs)
+	return joinStrings(msgs)
+}
+
+func TestValidateRequestParameters(t *testing.T) {
+	pm := NewTestContractPathMap(t)
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "200",
+		Query: map[string][]string{"limit": {"500"}, "status": {"available,sold"}}})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "200",
+		Query: map[string][]string{"limit": {"ten"}, "status": {"available,lost"}}})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "200"})
+	v := pm.Services["petstore"].PathItems["pet"].Verbs["GET"]
+	AreEqual(t, strings.Join([]string{
+		"missing required query parameter 'status' x1",
+		"query parameter 'limit' is greater than the maximum 100 x1",
+		"query parameter 'limit' is not a valid integer x1",
+		"query parameter 'status' is not one of the allowed values x1",
+	}, ","), violations(v), "Wrong query parameter violations")
+
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "abc"}, Response: "200"})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "12"}, Response: "200",
+		Headers: http.Header{"Accept": {"application/json"}}})
+	v = pm.Services["petstore"].PathItems["pet"].PathItems[ParameterisedItemKey].Verbs["GET"]
+	AreEqual(t, "missing required header parameter 'X-Trace' x1,path parameter 'petId' is not a valid integer x1", violations(v), "Wrong path and header violations")
+}
+
+func TestValidateBodies(t *testing.T) {
+	pm := NewTestContractPathMap(t)
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "PUT", Service: "petstore", PathElements: []string{"pet", "1"}, Response: "200",
+		JSONBody: map[string]interface{}{"name": "rex", "status": "available", "tags": []interface{}{map[string]interface{}{"id": 1.0}}}})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "PUT", Service: "petstore", PathElements: []string{"pet", "1"}, Response: "200",
+		JSONBody: map[string]interface{}{"status": "lost", "tags": []interface{}{map[string]interface{}{"id": "one"}}}})
+	v := pm.Services["petstore"].PathItems["pet"].PathItems[ParameterisedItemKey].Verbs["PUT"]
+	AreEqual(t, strings.Join([]string{
+		"body is missing required property 'name' x1",
+		"body.status is not one of the allowed values x1",
+		"body.tags[].id should be integer but is string x1",
+	}, ","), violations(v), "Wrong body violations")
+
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "1"}, Response: "200",
+		Headers: http.Header{"X-Trace": {"abc"}}, ResponseBody: []interface{}{}})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "1"}, Response: "200",
+		Headers: http.Header{"X-Trace": {"abc"}}, ResponseBody: map[string]interface{}{"name": "rex"}})
+	v = pm.Services["petstore"].PathItems["pet"].PathItems[ParameterisedItemKey].Verbs["GET"]
+	AreEqual(t, "response 200 body should be object but is array x1", violations(v), "Wrong response violations")
+}
+
+func TestValidateMaskedSumoValues(t *testing.T) {
+	pm := NewPathMap()
+	err := pm.ReadSwagger([]ServiceEntry{{RoutePath: "petstore", Swagger: FileURL("PetstoreSwagger.json")}})
+	AssertSuccess(t, err)
+	slr := &logs.SumoLogReaderInfo{}
+	for _, ll := range []string{"GET /petstore/pet/*,200", "GET /petstore/pet/findByStatus?status=*,200"} {
+		le, err := slr.ParseSumoLogEntry(ll)
+		AssertSuccess(t, err)
+		pm.CheckRequestLogEntry(le)
+	}
+	v := pm.Services["petstore"].PathItems["pet"].PathItems[ParameterisedItemKey].Verbs["GET"]
+	AreEqual(t, 1, v.Responses["200"].Covered, "Masked path not checked")
+	AreEqual(t, "", violations(v), "Masked path parameter validated")
+	v = pm.Services["petstore"].PathItems["pet"].PathItems["findByStatus"].Verbs["GET"]
+	AreEqual(t, 1, v.Responses["200"].Covered, "Masked query not checked")
+	AreEqual(t, "", violations(v), "Masked query parameter validated")
+}
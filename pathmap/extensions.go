@@ -0,0 +1,14 @@
+package pathmap
+
+//IgnoreExtension is the vendor extension that can be set to true on an operation
+//in a Swagger file to exclude the operation from the coverage report
+const IgnoreExtension = "x-coverage-ignore"
+
+//OwnerExtension is the vendor extension that names the team that owns an
+//operation. It can be set on an operation, or at the top level of a Swagger file
+//to set the owner of every operation in the service
+const OwnerExtension = "x-owner"
+
+//CriticalityExtension is the vendor extension that sets the criticality of an
+//operation e.g. "x-criticality": "high"
+const CriticalityExtension = "x-criticality"
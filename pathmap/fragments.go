@@ -0,0 +1,152 @@
+package pathmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/codeafix/apicovchk/swagger"
+	"github.com/go-openapi/spec"
+)
+
+//IgnoreFunc returns true if an item of a service is excluded from the coverage
+//report. The path is made up of the map keys of the path items, and an empty path,
+//method or response refers to the whole service, endpoint or verb respectively
+type IgnoreFunc func(service, path, method, response string) bool
+
+//DraftFragments returns a draft Swagger 2.0 document for each service containing
+//the paths of the undocumented items that were found in the logs. An undocumented
+//operation is described in full, with its path parameters, query and formData
+//parameters, response codes, and a schema inferred from any logged JSON bodies.
+//A documented operation only lists its undocumented parameters and responses, so
+//the fragment can be reviewed and merged into the service's Swagger file. Items
+//that the ignored function excludes from the report are not drafted, it may be nil
+func (pm *PathMap) DraftFragments(ignored IgnoreFunc) map[string]*spec.Swagger {
+	if ignored == nil {
+		ignored = func(service, path, method, response string) bool { return false }
+	}
+	drafts := map[string]*spec.Swagger{}
+	for sn, srv := range pm.Services {
+		if ignored(sn, "", "", "") {
+			continue
+		}
+		paths := map[string]spec.PathItem{}
+		draftPathItems(srv, "", "", []string{}, paths, func(path, method, response string) bool {
+			return ignored(sn, path, method, response)
+		})
+		if len(paths) == 0 {
+			continue
+		}
+		drafts[sn] = &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Swagger: "2.0",
+				Paths:   &spec.Paths{Paths: paths},
+			},
+		}
+	}
+	return drafts
+}
+
+//WriteDraftFragments writes the draft fragments of every service into the file as
+//a json object keyed by service name
+func (pm *PathMap) WriteDraftFragments(filename string, ignored IgnoreFunc) error {
+	c, err := json.MarshalIndent(pm.DraftFragments(ignored), "", "	")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, c, 0644)
+}
+
+//draftPathItems descends the PathItem maps adding a path to the paths map for each
+//endpoint that has undocumented items that aren't ignored. The names of the path
+//parameters on the way down are collected so that undocumented operations can
+//list them, and the map path is kept to check whether items are ignored
+func draftPathItems(pi *PathItem, path, mpath string, params []string, paths map[string]spec.PathItem, ignored func(path, method, response string) bool) {
+	for _, child := range pi.PathItems {
+		key := child.Key
+		cmpath := fmt.Sprintf("%s/%s", mpath, child.MapKey())
+		cparams := params
+		if child.IsParameter() {
+			name := uniqueParamName(strings.Trim(key, "{}"), params)
+			key = fmt.Sprintf("{%s}", name)
+			cparams = append(append([]string{}, params...), name)
+		}
+		cpath := fmt.Sprintf("%s/%s", path, key)
+		if child.Verbs != nil && !ignored(cmpath, "", "") {
+			spi := spec.PathItem{}
+			added := false
+			for _, verb := range child.Verbs {
+				if ignored(cmpath, verb.Name, "") {
+					continue
+				}
+				op := draftOperation(verb, cparams, func(response string) bool {
+					return ignored(cmpath, verb.Name, response)
+				})
+				if op != nil {
+					swagger.SetOperationForVerb(&spi, verb.Name, op)
+					added = true
+				}
+			}
+			if added {
+				paths[cpath] = spi
+			}
+		}
+		draftPathItems(child, cpath, cmpath, cparams, paths, ignored)
+	}
+}
+
+//uniqueParamName returns the name, with a number appended if it is already used
+//by another parameter in the path
+func uniqueParamName(name string, params []string) string {
+	unique := name
+	for i := 2; containsString(params, unique); i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	return unique
+}
+
+//draftOperation returns an operation describing the undocumented items of the verb,
+//or nil if everything about the verb is documented or ignored
+func draftOperation(verb *Verb, pathParams []string, ignored func(response string) bool) *spec.Operation {
+	if verb.Ignored {
+		return nil
+	}
+	op := &spec.Operation{}
+	added := false
+	if !verb.Documented {
+		for _, name := range pathParams {
+			op.AddParam(spec.PathParam(name).Typed("string", ""))
+		}
+		added = true
+	}
+	for _, param := range SortedParameters(verb.QueryParameters) {
+		if !param.Documented {
+			op.AddParam(spec.QueryParam(param.Key).Typed("string", ""))
+			added = true
+		}
+	}
+	for _, param := range SortedParameters(verb.FormParameters) {
+		if !param.Documented {
+			op.AddParam(spec.FormDataParam(param.Key).Typed("string", ""))
+			added = true
+		}
+	}
+	if !verb.Documented && verb.BodySchema != nil {
+		op.AddParam(spec.BodyParam("body", verb.BodySchema))
+	}
+	for code, resp := range verb.Responses {
+		status, err := strconv.Atoi(code)
+		if resp.Documented || err != nil || ignored(code) {
+			continue
+		}
+		op.RespondsWith(status, spec.NewResponse().WithDescription(http.StatusText(status)))
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	return op
+}
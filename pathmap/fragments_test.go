@@ -0,0 +1,85 @@
+package pathmap
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/go-openapi/spec"
+)
+
+func joinStrings(s []string) string {
+	return strings.Join(s, ",")
+}
+
+func propSchema(s *spec.Schema, name string) *spec.Schema {
+	prop := s.Properties[name]
+	return &prop
+}
+
+func paramNames(op *spec.Operation) string {
+	names := []string{}
+	for _, p := range op.Parameters {
+		names = append(names, p.In+":"+p.Name)
+	}
+	return joinStrings(names)
+}
+
+func TestDraftFragments(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/pet/{petId}":{"get":{"parameters":[{"name":"petId","in":"path","required":true,"type":"integer"}],"responses":{"200":{"description":"ok"}}}}
+	}}`
+	pm := NewPathMap()
+	idps, err := CompileIDPatterns([]string{"integer"})
+	AssertSuccess(t, err)
+	pm.IDPatterns = idps
+	MapSwagger(t, pm, "petstore", c)
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "1"}, Response: "200"})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "1"}, Response: "404", Query: map[string][]string{"verbose": {"true"}}})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "POST", Service: "petstore", PathElements: []string{"pet", "1", "photos", "7"}, Response: "201",
+		JSONBody: map[string]interface{}{"url": "http://photo", "size": 10.0}})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "POST", Service: "petstore", PathElements: []string{"pet", "1", "photos", "8"}, Response: "201",
+		JSONBody: map[string]interface{}{"url": "http://photo"}})
+
+	drafts := pm.DraftFragments(nil)
+	AreEqual(t, 1, len(drafts), "Wrong number of services")
+	paths := drafts["petstore"].Paths.Paths
+	AreEqual(t, 2, len(paths), "Wrong number of paths")
+
+	get := paths["/pet/{petId}"].Get
+	IsTrue(t, get != nil, "Documented operation with undocumented items not drafted")
+	AreEqual(t, "query:verbose", paramNames(get), "Only undocumented parameters expected")
+	AreEqual(t, 1, len(get.Responses.StatusCodeResponses), "Only undocumented responses expected")
+	AreEqual(t, "Not Found", get.Responses.StatusCodeResponses[404].Description, "Wrong response description")
+
+	post := paths["/pet/{petId}/photos/{id}"].Post
+	IsTrue(t, post != nil, "Undocumented operation not drafted")
+	AreEqual(t, "path:petId,path:id,body:body", paramNames(post), "Wrong parameters for undocumented operation")
+	body := post.Parameters[2].Schema
+	AreEqual(t, 2, len(body.Properties), "Body schema not inferred")
+	AreEqual(t, "url", joinStrings(body.Required), "Wrong required body properties")
+	IsTrue(t, post.Responses.StatusCodeResponses[201].Description == "Created", "Undocumented response not drafted")
+}
+
+func TestDraftFragmentsSkipIgnoredItems(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{"/pet":{"get":{"responses":{"200":{"description":"ok"}}}}}}`
+	pm := NewPathMap()
+	MapSwagger(t, pm, "petstore", c)
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "500"})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet"}, Response: "404"})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"health"}, Response: "200"})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "petstore", PathElements: []string{"pet", "1"}, Response: "200"})
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{Method: "GET", Service: "users", PathElements: []string{"user"}, Response: "200"})
+
+	drafts := pm.DraftFragments(func(service, path, method, response string) bool {
+		return service == "users" || path == "/health" || (path == "/pet/1" && method == "GET") || response == "500"
+	})
+	AreEqual(t, 1, len(drafts), "Ignored service drafted")
+	paths := drafts["petstore"].Paths.Paths
+	AreEqual(t, 1, len(paths), "Ignored endpoints drafted")
+	get := paths["/pet"].Get
+	IsTrue(t, get != nil, "Operation with undocumented responses not drafted")
+	AreEqual(t, 1, len(get.Responses.StatusCodeResponses), "Ignored response drafted")
+	IsTrue(t, get.Responses.StatusCodeResponses[404].Description == "Not Found", "Undocumented response not drafted")
+}
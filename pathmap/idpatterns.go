@@ -0,0 +1,47 @@
+package pathmap
+
+import (
+	"fmt"
+	"regexp"
+)
+
+//DetectedParameterKey is the key given to a PathItem created for an undocumented
+//path element that looks like an ID
+const DetectedParameterKey = "{id}"
+
+//BuiltInIDPatterns are the named patterns that can be listed in the idPatterns
+//option to detect common forms of ID in undocumented paths
+var BuiltInIDPatterns = map[string]string{
+	"uuid":    `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+	"integer": `^-?[0-9]+$`,
+	"hex":     `^(0x)?[0-9a-fA-F]{8,}$`,
+}
+
+//CompileIDPatterns compiles the passed list of ID patterns. Each pattern is either
+//the name of one of the BuiltInIDPatterns or a regular expression
+func CompileIDPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	rs := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		expr, exists := BuiltInIDPatterns[pattern]
+		if !exists {
+			expr = pattern
+		}
+		r, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid ID pattern '%s': %s", pattern, err.Error())
+		}
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
+
+//IsIDElement returns true if the passed path element matches any of the ID
+//patterns of this PathMap
+func (pm *PathMap) IsIDElement(element string) bool {
+	for _, r := range pm.IDPatterns {
+		if element != "" && r.MatchString(element) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package pathmap
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+)
+
+func TestCompileIDPatternsFailsWithInvalidPattern(t *testing.T) {
+	_, err := CompileIDPatterns([]string{"uuid", "("})
+	IsTrue(t, err != nil, "Invalid ID pattern did not fail")
+}
+
+func TestIsIDElement(t *testing.T) {
+	pm := NewPathMap()
+	idps, err := CompileIDPatterns([]string{"uuid", "integer", "hex", `^ORD-[0-9]+$`})
+	AssertSuccess(t, err)
+	pm.IDPatterns = idps
+	for _, el := range []string{"32a7e0b0-8130-4ab1-ace0-a81000890a14", "42", "0x1F2E3D4C", "deadbeef01", "ORD-1234"} {
+		IsTrue(t, pm.IsIDElement(el), "Expected ID element: "+el)
+	}
+	for _, el := range []string{"", "pet", "v3", "findByStatus", "abc", "ORD-"} {
+		IsFalse(t, pm.IsIDElement(el), "Unexpected ID element: "+el)
+	}
+}
+
+func TestCheckRequestLogEntryCollapsesIDs(t *testing.T) {
+	pm := NewPathMap()
+	idps, err := CompileIDPatterns([]string{"uuid", "integer"})
+	AssertSuccess(t, err)
+	pm.IDPatterns = idps
+	for _, id := range []string{"32a7e0b0-8130-4ab1-ace0-a81000890a14", "0af343ae-4468-44e5-98aa-897e6e6c5458", "17"} {
+		pm.CheckRequestLogEntry(logs.RequestLogEntry{
+			Method:       "GET",
+			PathElements: []string{"client", id, "periods"},
+			Service:      "orchestration",
+			Response:     "200",
+		})
+	}
+	client := pm.Services["orchestration"].PathItems["client"]
+	AreEqual(t, 1, len(client.PathItems), "IDs not collapsed into a parameter")
+	v := client.PathItems[ParameterisedItemKey].PathItems["periods"].Verbs["GET"]
+	AreEqual(t, 3, v.Responses["200"].Covered, "Wrong coverage count")
+	IsFalse(t, v.Documented, "Verb should be undocumented")
+}
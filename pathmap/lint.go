@@ -0,0 +1,129 @@
+package pathmap
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codeafix/apicovchk/swagger"
+	"github.com/go-openapi/spec"
+)
+
+//LintFinding records a quality problem found in a Swagger description
+type LintFinding struct {
+	Service string
+	Path    string
+	Method  string
+	Message string
+}
+
+//LintOperation returns the quality findings for an operation in a Swagger file
+func LintOperation(service, path, verb string, op *spec.Operation) []LintFinding {
+	msgs := []string{}
+	if op.ID == "" {
+		msgs = append(msgs, "missing operationId")
+	}
+	if !hasResponses(op.Responses) {
+		msgs = append(msgs, "no documented responses")
+	} else if !hasErrorResponse(op.Responses) {
+		msgs = append(msgs, "no documented error responses")
+	}
+	for _, param := range op.Parameters {
+		if param.Ref.String() != "" {
+			continue
+		}
+		if param.In == "body" && param.Schema == nil {
+			msgs = append(msgs, fmt.Sprintf("body parameter '%s' has no schema", param.Name))
+		}
+		if param.In != "body" && param.Type == "" {
+			msgs = append(msgs, fmt.Sprintf("%s parameter '%s' has no type", param.In, param.Name))
+		}
+	}
+	findings := []LintFinding{}
+	for _, msg := range msgs {
+		findings = append(findings, LintFinding{Service: service, Path: path, Method: verb, Message: msg})
+	}
+	return findings
+}
+
+//hasResponses returns true if the responses document a code, a range or a default
+//response
+func hasResponses(r *spec.Responses) bool {
+	if r == nil {
+		return false
+	}
+	if len(r.StatusCodeResponses) > 0 || r.Default != nil {
+		return true
+	}
+	ranges, _ := r.Extensions.GetStringSlice(swagger.ResponseRangesExtension)
+	return len(ranges) > 0
+}
+
+//hasErrorResponse returns true if the responses document a 4xx or 5xx code, an
+//error range, or a default response
+func hasErrorResponse(r *spec.Responses) bool {
+	if r.Default != nil {
+		return true
+	}
+	for code := range r.StatusCodeResponses {
+		if code >= 400 {
+			return true
+		}
+	}
+	ranges, _ := r.Extensions.GetStringSlice(swagger.ResponseRangesExtension)
+	for _, code := range ranges {
+		if class := ResponseClass(strings.ToUpper(code)); class == ClientErrorClass || class == ServerErrorClass {
+			return true
+		}
+	}
+	return false
+}
+
+var pathParamRegexp = regexp.MustCompile(`\{[^/]*\}`)
+
+//LintSwaggerPaths returns the quality findings for every operation in the Swagger
+//file, and for paths that are the same apart from the names of their parameters
+func LintSwaggerPaths(service string, swgr *spec.Swagger) []LintFinding {
+	findings := []LintFinding{}
+	templates := map[string][]string{}
+	for path, spi := range swgr.Paths.Paths {
+		for _, verb := range MethodOrder {
+			if op := swagger.OperationForVerb(&spi, verb); op != nil {
+				findings = append(findings, LintOperation(service, path, verb, op)...)
+			}
+		}
+		template := pathParamRegexp.ReplaceAllString(path, ParameterisedItemKey)
+		templates[template] = append(templates[template], path)
+	}
+	for _, paths := range templates {
+		if len(paths) < 2 {
+			continue
+		}
+		sort.Strings(paths)
+		for i, path := range paths {
+			others := append(append([]string{}, paths[:i]...), paths[i+1:]...)
+			findings = append(findings, LintFinding{
+				Service: service,
+				Path:    path,
+				Message: fmt.Sprintf("differs only by parameter names from %s", strings.Join(others, ", ")),
+			})
+		}
+	}
+	SortFindings(findings)
+	return findings
+}
+
+//SortFindings sorts the findings by path, method and message
+func SortFindings(findings []LintFinding) {
+	sort.Slice(findings, func(i, j int) bool {
+		fi, fj := findings[i], findings[j]
+		if fi.Path != fj.Path {
+			return fi.Path < fj.Path
+		}
+		if fi.Method != fj.Method {
+			return fi.Method < fj.Method
+		}
+		return fi.Message < fj.Message
+	})
+}
@@ -0,0 +1,42 @@
+package pathmap
+
+import (
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/swagger"
+)
+
+func TestMapSwaggerPathsCollectsFindings(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/pet/{id}":{"get":{"operationId":"getPet","responses":{"200":{"description":"ok"},"404":{"description":"none"}}}},
+		"/pet/{petId}":{"get":{"operationId":"findPet","responses":{"200":{"description":"ok"},"404":{"description":"none"}}},
+			"put":{"operationId":"updatePet","parameters":[{"name":"body","in":"body"},{"name":"force","in":"query"}],
+			"responses":{"200":{"description":"ok"}}}},
+		"/store":{"get":{"responses":{}},
+			"post":{"operationId":"placeOrder","responses":{"2XX":{"description":"ok"},"4XX":{"description":"error"}}}},
+		"/user":{"get":{"operationId":"getUser","responses":{"200":{"description":"ok"},"5XX":{"description":"error"}}}}
+	}}`
+	pm := NewPathMap()
+	MapSwagger(t, pm, "petstore", c, swagger.AddResponseRanges)
+	expected := []LintFinding{
+		{Service: "petstore", Path: "/pet/{id}", Message: "differs only by parameter names from /pet/{petId}"},
+		{Service: "petstore", Path: "/pet/{petId}", Message: "differs only by parameter names from /pet/{id}"},
+		{Service: "petstore", Path: "/pet/{petId}", Method: "GET", Message: "multiple definitions of the verb, the definition on /pet/{id} is used"},
+		{Service: "petstore", Path: "/pet/{petId}", Method: "PUT", Message: "body parameter 'body' has no schema"},
+		{Service: "petstore", Path: "/pet/{petId}", Method: "PUT", Message: "no documented error responses"},
+		{Service: "petstore", Path: "/pet/{petId}", Method: "PUT", Message: "query parameter 'force' has no type"},
+		{Service: "petstore", Path: "/store", Method: "GET", Message: "missing operationId"},
+		{Service: "petstore", Path: "/store", Method: "GET", Message: "no documented responses"},
+	}
+	AreEqual(t, len(expected), len(pm.Findings), "Wrong number of findings")
+	for i, lf := range expected {
+		if i < len(pm.Findings) {
+			AreEqual(t, lf, pm.Findings[i], "Wrong finding")
+		}
+	}
+	pi := pm.Services["petstore"].PathItems["pet"].PathItems[ParameterisedItemKey]
+	AreEqual(t, "getPet", pi.Verbs["GET"].OperationID, "First definition not kept")
+	AreEqual(t, "{id}", pi.Verbs["GET"].Contract.PathTemplate[1], "Contract of the first definition not kept")
+	AreEqual(t, "updatePet", pi.Verbs["PUT"].OperationID, "Other verbs of the path not added")
+}
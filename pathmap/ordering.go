@@ -0,0 +1,86 @@
+package pathmap
+
+import (
+	"sort"
+)
+
+//MethodOrder is the canonical order that the verbs of an endpoint are read from the
+//Swagger file and reported in. Any other methods are reported after these in alphabetical order
+var MethodOrder = []string{"GET", "PUT", "POST", "DELETE", "HEAD", "OPTIONS", "PATCH"}
+
+//methodRank returns the position of the method in the canonical order
+func methodRank(method string) int {
+	for i, m := range MethodOrder {
+		if m == method {
+			return i
+		}
+	}
+	return len(MethodOrder)
+}
+
+//SortedVerbs returns the verbs in the canonical method order
+func SortedVerbs(verbs map[string]*Verb) []*Verb {
+	sorted := []*Verb{}
+	for _, verb := range verbs {
+		sorted = append(sorted, verb)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		ri, rj := methodRank(sorted[i].Name), methodRank(sorted[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+//SortedPathItems returns the child PathItems in the order of their map keys, so a
+//literal path element is reported before a parameter
+func SortedPathItems(items map[string]*PathItem) []*PathItem {
+	keys := []string{}
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sorted := []*PathItem{}
+	for _, key := range keys {
+		sorted = append(sorted, items[key])
+	}
+	return sorted
+}
+
+//SortedResponses returns the responses ordered by code. Explicit codes are listed
+//before the range that contains them, and the default response is last
+func SortedResponses(responses map[string]*Response) []*Response {
+	sorted := []*Response{}
+	for _, resp := range responses {
+		sorted = append(sorted, resp)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Response < sorted[j].Response
+	})
+	return sorted
+}
+
+//SortedParameters returns the parameters ordered by name
+func SortedParameters(params map[string]*QueryParameter) []*QueryParameter {
+	sorted := []*QueryParameter{}
+	for _, param := range params {
+		sorted = append(sorted, param)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Key < sorted[j].Key
+	})
+	return sorted
+}
+
+//SortedServiceNames returns the names of the services in the PathMap in
+//alphabetical order
+func (pm *PathMap) SortedServiceNames() []string {
+	names := []string{}
+	for name := range pm.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,38 @@
+package pathmap
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+)
+
+func TestSortedVerbs(t *testing.T) {
+	verbs := map[string]*Verb{}
+	for _, name := range []string{"PATCH", "TRACE", "DELETE", "GET", "POST", "HEAD", "PUT", "OPTIONS", "CONNECT"} {
+		verbs[name] = NewVerb(name, true, []string{}, []string{})
+	}
+	names := []string{}
+	for _, verb := range SortedVerbs(verbs) {
+		names = append(names, verb.Name)
+	}
+	AreEqual(t, "GET,PUT,POST,DELETE,HEAD,OPTIONS,PATCH,CONNECT,TRACE", strings.Join(names, ","), "Verbs not in canonical order")
+}
+
+func TestSortedResponsesAndParameters(t *testing.T) {
+	responses := map[string]*Response{}
+	for _, code := range []string{"default", "404", "4XX", "200", "500"} {
+		responses[code] = &Response{Response: code}
+	}
+	codes := []string{}
+	for _, resp := range SortedResponses(responses) {
+		codes = append(codes, resp.Response)
+	}
+	AreEqual(t, "200,404,4XX,500,default", strings.Join(codes, ","), "Responses not sorted")
+	params := map[string]*QueryParameter{"status": {Key: "status"}, "limit": {Key: "limit"}, "offset": {Key: "offset"}}
+	keys := []string{}
+	for _, param := range SortedParameters(params) {
+		keys = append(keys, param.Key)
+	}
+	AreEqual(t, "limit,offset,status", strings.Join(keys, ","), "Parameters not sorted")
+}
@@ -0,0 +1,488 @@
+//Package pathmap maps the paths of the services described by Swagger files into a
+//tree, routes logged requests to the services, and records the parameters and
+//responses each request covers against the documented operations
+package pathmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/swagger"
+	"github.com/go-openapi/spec"
+)
+
+//PathMap contains the list of all paths defined in the Swagger files for each
+//service
+type PathMap struct {
+	Services        map[string]*PathItem `json:"services"`
+	Routes          []ServiceRoute       `json:"-"`
+	CaseInsensitive bool                 `json:"-"`
+	IDPatterns      []*regexp.Regexp     `json:"-"`
+	Findings        []LintFinding        `json:"-"`
+	Credentials     []Credential         `json:"-"`
+}
+
+//PathItem represents a single element from a path defined in a Swagger file
+type PathItem struct {
+	Key        string               `json:"key"`
+	PathItems  map[string]*PathItem `json:"pathItems"`
+	Verbs      map[string]*Verb     `json:"verbs"`
+	Documented bool                 `json:"documented"`
+}
+
+//ParameterisedItemKey is the string put into the PathItem maps when the path item
+//is parameterised so that map will always match even if the parameters are defined
+//with different names in the swagger files
+const ParameterisedItemKey = "{*}"
+
+//Verb represents a verb applied to a path and contains the list of defined accept
+//header, content-type header, and response code combinations that are documented
+//as supported in the Swagger file
+type Verb struct {
+	Name            string                     `json:"name"`
+	Produces        []string                   `json:"produces"`
+	Consumes        []string                   `json:"consumes"`
+	Responses       map[string]*Response       `json:"responses"`
+	QueryParameters map[string]*QueryParameter `json:"queryParams"`
+	FormParameters  map[string]*QueryParameter `json:"formParams"`
+	Security        *Security                  `json:"security,omitempty"`
+	Documented      bool                       `json:"documented"`
+	Ignored         bool                       `json:"ignored,omitempty"`
+	Deprecated      bool                       `json:"deprecated,omitempty"`
+	Tags            []string                   `json:"tags,omitempty"`
+	OperationID     string                     `json:"operationId,omitempty"`
+	Summary         string                     `json:"summary,omitempty"`
+	Owner           string                     `json:"owner,omitempty"`
+	Criticality     string                     `json:"criticality,omitempty"`
+	BodySchema      *spec.Schema               `json:"-"`
+	Contract        *Contract                  `json:"-"`
+	Violations      map[string]int             `json:"-"`
+}
+
+//DefaultResponseKey is the key of the response that documents all of the response
+//codes that are not explicitly documented for an operation
+const DefaultResponseKey = "default"
+
+//Response represents a possible response code for the documented http request.
+//Range (e.g. "4XX") and default responses record the logged codes they matched
+type Response struct {
+	Response   string   `json:"code"`
+	Covered    int      `json:"covered"`
+	Documented bool     `json:"documented"`
+	Matched    []string `json:"matched,omitempty"`
+	Ignored    bool     `json:"-"`
+}
+
+//DisplayName returns the response code with, for range and default responses, the
+//logged response codes that were matched against it
+func (r *Response) DisplayName() string {
+	if len(r.Matched) == 0 {
+		return r.Response
+	}
+	return fmt.Sprintf("%s (%s)", r.Response, strings.Join(r.Matched, ", "))
+}
+
+//Calls returns the number of logged requests to the verb
+func (v *Verb) Calls() int {
+	calls := 0
+	for _, resp := range v.Responses {
+		calls = calls + resp.Covered
+	}
+	return calls
+}
+
+//FindResponse returns the documented response that the logged response code matches.
+//An explicitly documented code is matched first, then a range (e.g. "4XX"), then
+//the default response
+func (v *Verb) FindResponse(code string) (*Response, bool) {
+	resp, exists := v.Responses[code]
+	if exists {
+		return resp, true
+	}
+	if len(code) == 3 {
+		resp, exists = v.Responses[code[0:1]+"XX"]
+		if exists {
+			return resp, true
+		}
+	}
+	resp, exists = v.Responses[DefaultResponseKey]
+	return resp, exists
+}
+
+//Match records that the logged response code was matched against this range or
+//default response
+func (r *Response) Match(code string) {
+	for _, m := range r.Matched {
+		if m == code {
+			return
+		}
+	}
+	r.Matched = append(r.Matched, code)
+	sort.Strings(r.Matched)
+}
+
+//QueryParameter represents a possible query or formData parameter for the documented http request
+type QueryParameter struct {
+	Key        string `json:"key"`
+	Covered    int    `json:"covered"`
+	Documented bool   `json:"documented"`
+	Required   bool   `json:"required"`
+}
+
+//NewPathMap constructs a PathMap and returns it
+func NewPathMap() *PathMap {
+	return &PathMap{
+		Services: map[string]*PathItem{},
+	}
+}
+
+//IsParameter returns true if this PathItem is a route parameter
+func (p *PathItem) IsParameter() bool {
+	r := regexp.MustCompile(`^\{[^\}]*\}$`)
+	return r.MatchString(p.Key)
+}
+
+//MapKey returns a string that can be used to add the PathItem into a Map
+//Note if the item is a path parameter this method returns "{*}" so that
+//path parameters that are specified differently, but exist in the same
+//route path position will still match
+func (p *PathItem) MapKey() string {
+	if p.IsParameter() {
+		return ParameterisedItemKey
+	}
+	return p.Key
+}
+
+//ParameterisedChild returns true if the PathItems map of this PathItem
+//contains a parameterised path item. This means that the child element
+//in the path is parameterised e.g. /thisPathItem/{childItem}
+func (p *PathItem) ParameterisedChild() bool {
+	_, exists := p.PathItems[ParameterisedItemKey]
+	return exists
+}
+
+//NewPathItem returns a new instance of PathItem for the given path element
+func NewPathItem(pathelement string, documented bool) *PathItem {
+	return &PathItem{
+		Key:        pathelement,
+		PathItems:  map[string]*PathItem{},
+		Documented: documented,
+	}
+}
+
+//NewVerb creates a new instance of Verb with the specified name
+func NewVerb(verb string, documented bool, produces, consumes []string) *Verb {
+	return &Verb{
+		Name:            verb,
+		Consumes:        consumes,
+		Produces:        produces,
+		Responses:       map[string]*Response{},
+		QueryParameters: map[string]*QueryParameter{},
+		FormParameters:  map[string]*QueryParameter{},
+		Documented:      documented,
+	}
+}
+
+//ReadSwagger reads the Swagger file of each of the passed services and adds its
+//paths to the PathMap. A service without a name, route path or host is named
+//after the Swagger host or basePath it is configured to use. A service with no
+//route path or host to route requests by is rejected
+func (pm *PathMap) ReadSwagger(services []ServiceEntry) error {
+	for _, srv := range services {
+		sr, err := swagger.NewSwaggerReader(srv.Swagger)
+		if err != nil {
+			return err
+		}
+		swgr, err := sr.GetSwaggerContent()
+		if err != nil {
+			return err
+		}
+		route := NewServiceRoute(srv, swgr)
+		if len(route.Prefix) == 0 && route.Host == "" {
+			return fmt.Errorf("Service with swagger '%s' has no route. Set its routePath or host, or use the host or basePath in the Swagger file", srv.Swagger)
+		}
+		name := route.Service
+		_, exists := pm.Services[name]
+		if exists {
+			return fmt.Errorf("Swagger for service '%s' already read. Check you haven't included the same file more than once", name)
+		}
+		err = pm.MapSwaggerPaths(name, swgr)
+		if err != nil {
+			return err
+		}
+		pm.AddRoute(route)
+	}
+	return nil
+}
+
+//MapSwaggerPaths adds all of the paths from the passed Swagger definition and
+//collects the quality findings for the definition. The paths are added in order,
+//and when paths that only differ by the names of their parameters define the same
+//verb the first definition is kept and the collision is recorded as a finding
+func (pm *PathMap) MapSwaggerPaths(route string, swgr *spec.Swagger) error {
+	pi := NewPathItem(route, true)
+	pm.Services[route] = pi
+	findings := LintSwaggerPaths(route, swgr)
+	pm.Credentials = append(pm.Credentials, APIKeyCredentials(swgr)...)
+	paths := make([]string, 0, len(swgr.Paths.Paths))
+	for path := range swgr.Paths.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	definedBy := map[*Verb]string{}
+	for _, path := range paths {
+		spi := swgr.Paths.Paths[path]
+		mpath := path
+		if pm.CaseInsensitive {
+			mpath = strings.ToLower(path)
+		}
+		//Paths in Swagger should always begin with '/' so discard the first empty string
+		lpi := pm.MapElementPath(pi, strings.Split(mpath, "/"), 1, true)
+		for verb, v := range lpi.Verbs {
+			if first, exists := definedBy[v]; exists && swagger.OperationForVerb(&spi, verb) != nil {
+				findings = append(findings, LintFinding{
+					Service: route,
+					Path:    path,
+					Method:  verb,
+					Message: fmt.Sprintf("multiple definitions of the verb, the definition on %s is used", first),
+				})
+				swagger.SetOperationForVerb(&spi, verb, nil)
+			}
+		}
+		err := pm.AddVerbToPathItem(lpi, spi, swgr)
+		if err != nil {
+			return fmt.Errorf("Error adding path '%s': %s", path, err.Error())
+		}
+		for name, v := range lpi.Verbs {
+			if op := swagger.OperationForVerb(&spi, name); op != nil {
+				v.Contract = NewContract(path, spi, op, swgr)
+				definedBy[v] = path
+			}
+		}
+	}
+	SortFindings(findings)
+	pm.Findings = append(pm.Findings, findings...)
+	return nil
+}
+
+//AddVerbToPathItem adds the information for the swagger endpoint to the PathItem map
+func (pm *PathMap) AddVerbToPathItem(pi *PathItem, spi spec.PathItem, swgr *spec.Swagger) error {
+	for _, verb := range MethodOrder {
+		op := swagger.OperationForVerb(&spi, verb)
+		if op == nil {
+			continue
+		}
+		err := pm.CreateAndAddVerb(pi, verb, op, swgr)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//CreateAndAddVerb ensures the passed verb is added to the passed PathItem
+func (pm *PathMap) CreateAndAddVerb(pi *PathItem, verb string, op *spec.Operation, swgr *spec.Swagger) error {
+	v, exists := pi.Verbs[verb]
+	if !exists {
+		v = NewVerb(verb, true, op.Produces, op.Consumes)
+		v.Security = NewSecurity(op, swgr)
+		v.Ignored, _ = op.Extensions.GetBool(IgnoreExtension)
+		v.Deprecated = op.Deprecated
+		v.Tags = op.Tags
+		v.OperationID = op.ID
+		v.Summary = op.Summary
+		v.Owner, _ = op.Extensions.GetString(OwnerExtension)
+		if v.Owner == "" && swgr != nil {
+			v.Owner, _ = swgr.Extensions.GetString(OwnerExtension)
+		}
+		v.Criticality, _ = op.Extensions.GetString(CriticalityExtension)
+		pi.Verbs[verb] = v
+		if op.Responses != nil {
+			for code := range op.Responses.StatusCodeResponses {
+				strcode := strconv.Itoa(code)
+				v.Responses[strcode] = &Response{
+					Response:   strcode,
+					Documented: true,
+				}
+			}
+			ranges, _ := op.Responses.Extensions.GetStringSlice(swagger.ResponseRangesExtension)
+			for _, code := range ranges {
+				code = strings.ToUpper(code)
+				v.Responses[code] = &Response{
+					Response:   code,
+					Documented: true,
+				}
+			}
+			if op.Responses.Default != nil {
+				v.Responses[DefaultResponseKey] = &Response{
+					Response:   DefaultResponseKey,
+					Documented: true,
+				}
+			}
+		}
+		for _, param := range op.Parameters {
+			switch param.In {
+			case "query":
+				v.QueryParameters[param.Name] = &QueryParameter{
+					Key:        param.Name,
+					Documented: true,
+					Required:   param.Required,
+				}
+			case "formData":
+				v.FormParameters[param.Name] = &QueryParameter{
+					Key:        param.Name,
+					Documented: true,
+					Required:   param.Required,
+				}
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("Multiple definitions of the '%s' verb on the same path", verb)
+}
+
+//MapElementPath adds the path elements into the PathItem maps creating them as necessary
+//and returns the leaf PathItem. When an undocumented path element looks like an ID
+//it is added as a parameter so that requests for different IDs share the PathItem
+func (pm *PathMap) MapElementPath(parent *PathItem, elements []string, index int, documented bool) *PathItem {
+	item := NewPathItem(elements[index], documented)
+	pi, exists := parent.PathItems[item.MapKey()]
+	if !exists {
+		if !documented && parent.ParameterisedChild() {
+			//Match this element against the parameter
+			pi = parent.PathItems[ParameterisedItemKey]
+		} else {
+			if !documented && pm.IsIDElement(elements[index]) {
+				item = NewPathItem(DetectedParameterKey, documented)
+			}
+			pi = item
+			parent.PathItems[item.MapKey()] = item
+		}
+	}
+	if index+1 == len(elements) {
+		if pi.Verbs == nil {
+			pi.Verbs = map[string]*Verb{}
+		}
+		return pi
+	}
+	return pm.MapElementPath(pi, elements, index+1, documented)
+}
+
+//MatchPath finds the endpoint that the path elements of a request route to using the
+//same precedence rules as common http routers. At each level a literal path element
+//is matched before a parameter, and if a branch does not lead to an endpoint the
+//search backtracks and tries the parameter instead. An endpoint that defines the
+//request's verb is preferred over one that doesn't. Returns nil if no endpoint matches
+func (pm *PathMap) MatchPath(srv *PathItem, elements []string, method string) *PathItem {
+	match := func(pi *PathItem) bool {
+		_, exists := pi.Verbs[method]
+		return exists
+	}
+	lpi := pm.matchElementPath(srv, elements, 0, match)
+	if lpi == nil {
+		lpi = pm.matchElementPath(srv, elements, 0, func(pi *PathItem) bool {
+			return len(pi.Verbs) > 0
+		})
+	}
+	return lpi
+}
+
+//matchElementPath descends the PathItem maps depth first trying the literal child
+//before the parameterised child and returns the first endpoint accepted by match
+func (pm *PathMap) matchElementPath(parent *PathItem, elements []string, index int, match func(*PathItem) bool) *PathItem {
+	if index == len(elements) {
+		if parent.Verbs != nil && match(parent) {
+			return parent
+		}
+		return nil
+	}
+	for _, key := range []string{elements[index], ParameterisedItemKey} {
+		child, exists := parent.PathItems[key]
+		if !exists || (key == ParameterisedItemKey && elements[index] == "") {
+			continue
+		}
+		lpi := pm.matchElementPath(child, elements, index+1, match)
+		if lpi != nil {
+			return lpi
+		}
+	}
+	return nil
+}
+
+//JSON returns the content of this PathMap as a json object structure
+func (pm *PathMap) JSON() string {
+	c, _ := json.MarshalIndent(pm, "", "	")
+	return string(c)
+}
+
+//CheckRequestLogEntry checks the passed request log entry against
+//this PathMap. The entry is first routed to a service using the service
+//routes read from the configuration. If necessary it will add PathItems if the URL is not
+//documented. Verbs, Query Parameters, Response codes used in the log entry
+//that are documented will have their coverage count incremented
+func (pm *PathMap) CheckRequestLogEntry(le logs.RequestLogEntry) {
+	pm.RouteRequestLogEntry(&le)
+	srv, exists := pm.Services[le.Service]
+	if !exists {
+		srv = NewPathItem(le.Service, false)
+		pm.Services[le.Service] = srv
+	}
+	if len(le.PathElements) == 0 {
+		//A request to the root of the service
+		le.PathElements = []string{""}
+	}
+	lpi := pm.MatchPath(srv, le.PathElements, le.Method)
+	if lpi == nil {
+		lpi = pm.MapElementPath(srv, le.PathElements, 0, false)
+	}
+	v, exists := lpi.Verbs[le.Method]
+	if !exists {
+		v = NewVerb(le.Method, false, []string{}, []string{})
+		lpi.Verbs[le.Method] = v
+	}
+	resp, exists := v.FindResponse(le.Response)
+	if exists && resp.Response != le.Response {
+		resp.Match(le.Response)
+	}
+	if !exists {
+		resp = &Response{
+			Response:   le.Response,
+			Documented: false,
+		}
+		v.Responses[le.Response] = resp
+	}
+	resp.Covered = resp.Covered + 1
+	if v.Security != nil {
+		v.Security.CheckRequestLogEntry(le)
+	}
+	if v.Contract != nil {
+		v.RecordViolations(v.Contract.Validate(le, resp.Response))
+	}
+	CoverParameters(v.QueryParameters, le.Query)
+	CoverParameters(v.FormParameters, le.Form)
+	if !v.Documented && le.JSONBody != nil {
+		v.BodySchema = MergeSchema(v.BodySchema, InferSchema(le.JSONBody))
+	}
+}
+
+//CoverParameters increments the coverage count of each of the passed parameters
+//that were used in a request, adding any that are not documented
+func CoverParameters(params map[string]*QueryParameter, used url.Values) {
+	for elem := range used {
+		param, exists := params[elem]
+		if !exists {
+			param = &QueryParameter{
+				Key:        elem,
+				Documented: false,
+			}
+			params[elem] = param
+		}
+		param.Covered = param.Covered + 1
+	}
+}
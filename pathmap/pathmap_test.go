@@ -0,0 +1,235 @@
+package pathmap
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/swagger"
+	"github.com/google/uuid"
+)
+
+func TestAddSwaggerToPathMap(t *testing.T) {
+	services := []ServiceEntry{
+		ServiceEntry{
+			RoutePath: "petstore",
+			Swagger:   FileURL("PetstoreSwagger.json"),
+		},
+	}
+	pm := NewPathMap()
+	err := pm.ReadSwagger(services)
+	AssertSuccess(t, err)
+	CheckGold(t, "PathMapFromSwaggerTest.json", pm.JSON())
+}
+
+func CheckTransactionLogEntry(t *testing.T) {
+	lr := logs.NewTransactionLogReader()
+	err := lr.SetLogURL(FileURL("coverage-report.txt"))
+	AssertSuccess(t, err)
+	pm := NewPathMap()
+	lel, err := lr.GetLogEntries()
+	AssertSuccess(t, err)
+	for _, entry := range lel {
+		pm.CheckRequestLogEntry(entry)
+	}
+	CheckGold(t, "PathMapFromLogTest.json", pm.JSON())
+}
+
+func GenerateAccessLogFromSwagger(t *testing.T) {
+	entries := [][7]string{}
+	sumo := true
+	domain := "https://127.0.0.1:8081"
+	if sumo {
+		domain = ""
+	}
+
+	GenerateEntries(t, &entries, domain)
+
+	f, err := os.Create(TempPath("petstore-log.txt"))
+	AssertSuccess(t, err)
+	defer f.Close()
+	if sumo {
+		f.WriteString("API,Response Code\n")
+	} else {
+		f.WriteString("duration(ms)\tstart-time\tend-time\tmethod\turl\tbody\tresponse\n")
+	}
+	for _, line := range entries {
+		s := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\n", line[0], line[1], line[2], line[3], line[4], line[5], line[6])
+		if sumo {
+			s = fmt.Sprintf("%s %s,%s\n", line[3], line[4], line[6])
+		}
+		f.WriteString(s)
+	}
+}
+
+func GenerateEntries(t *testing.T, entries *[][7]string, domain string) {
+	services := []ServiceEntry{
+		ServiceEntry{
+			RoutePath: "petstore",
+			Swagger:   FileURL("PetStoreSwagger.json"),
+		},
+	}
+	pm := NewPathMap()
+	err := pm.ReadSwagger(services)
+	AssertSuccess(t, err)
+	for _, pi := range pm.Services["petstore"].PathItems {
+		WalkPathItems(entries, pi, fmt.Sprintf("%s/petstore", domain))
+	}
+}
+
+func WalkPathItems(entries *[][7]string, pi *PathItem, path string) {
+
+	key := pi.MapKey()
+	if pi.IsParameter() {
+		id, _ := uuid.NewUUID()
+		key = id.String()
+	}
+	cpath := fmt.Sprintf("%s/%s", path, key)
+	if pi.Verbs != nil {
+		for _, verb := range pi.Verbs {
+			if len(verb.Responses) > 0 {
+				for _, response := range verb.Responses {
+					entry := [7]string{
+						"0",
+						"0",
+						"0",
+						verb.Name,
+						cpath,
+						"undefined",
+						response.Response,
+					}
+					*entries = append(*entries, entry)
+				}
+			} else {
+				entry := [7]string{
+					"0",
+					"0",
+					"0",
+					verb.Name,
+					cpath,
+					"undefined",
+					"200",
+				}
+				*entries = append(*entries, entry)
+			}
+			if len(verb.QueryParameters) > 0 {
+				i := 0
+				query := []string{}
+				for _, param := range verb.QueryParameters {
+					query = append(query, fmt.Sprintf("%s=val%d", param.Key, i))
+					i++
+				}
+				cpath = fmt.Sprintf("%s?%s", cpath, strings.Join(query, "&"))
+				entry := [7]string{
+					"0",
+					"0",
+					"0",
+					verb.Name,
+					cpath,
+					"undefined",
+					"200",
+				}
+				*entries = append(*entries, entry)
+			}
+		}
+	}
+	for _, child := range pi.PathItems {
+		WalkPathItems(entries, child, cpath)
+	}
+}
+
+func TestCheckRequestLogEntryMatchesRangeAndDefaultResponses(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{"/pets":{"get":{"responses":{"200":{"description":"ok"},"4XX":{"description":"client error"},"default":{"description":"error"}}}}}}`
+	pm := NewPathMap()
+	MapSwagger(t, pm, "petstore", c, swagger.AddResponseRanges)
+	for _, code := range []string{"200", "404", "400", "404", "503"} {
+		pm.CheckRequestLogEntry(logs.RequestLogEntry{
+			Method:       "GET",
+			PathElements: []string{"pets"},
+			Service:      "petstore",
+			Response:     code,
+		})
+	}
+	v := pm.Services["petstore"].PathItems["pets"].Verbs["GET"]
+	AreEqual(t, 3, len(v.Responses), "Logged codes added as undocumented responses")
+	AreEqual(t, 1, v.Responses["200"].Covered, "Wrong coverage of 200")
+	AreEqual(t, 3, v.Responses["4XX"].Covered, "Wrong coverage of 4XX")
+	AreEqual(t, "4XX (400, 404)", v.Responses["4XX"].DisplayName(), "Wrong display name of 4XX")
+	AreEqual(t, "default (503)", v.Responses[DefaultResponseKey].DisplayName(), "Wrong display name of default")
+}
+
+func NewTestRoutingPathMap(t *testing.T) *PathMap {
+	c := `{"swagger":"2.0","paths":{
+		"/pet/findByStatus":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/pet/{petId}":{"get":{"responses":{"200":{"description":"ok"}}},"delete":{"responses":{"200":{"description":"ok"}}}},
+		"/pet/{petId}/uploadImage":{"post":{"responses":{"200":{"description":"ok"}}}},
+		"/store/order/{orderId}":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/store/{storeId}/items":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/users/me":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/users/{id}/friends/{friendId}":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/users/{userId}/friends/best":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/a/b/c":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/a/{x}/{y}":{"get":{"responses":{"200":{"description":"ok"}}}}
+	}}`
+	pm := NewPathMap()
+	MapSwagger(t, pm, "petstore", c)
+	return pm
+}
+
+func TestMatchPathPrecedence(t *testing.T) {
+	pm := NewTestRoutingPathMap(t)
+	srv := pm.Services["petstore"]
+	routes := []struct {
+		method string
+		path   string
+		route  string
+	}{
+		//Literal before parameter
+		{"GET", "pet/findByStatus", "findByStatus"},
+		{"GET", "pet/123", ParameterisedItemKey},
+		//Prefers the endpoint defining the verb
+		{"DELETE", "pet/findByStatus", ParameterisedItemKey},
+		//Backtrack to the parameter when the literal branch dead ends
+		{"POST", "pet/findByStatus/uploadImage", "uploadImage"},
+		{"GET", "store/order/items", ParameterisedItemKey},
+		{"GET", "store/7/items", "items"},
+		{"GET", "users/me/friends/best", "best"},
+		{"GET", "users/me/friends/42", ParameterisedItemKey},
+		{"GET", "a/b/c", "c"},
+		{"GET", "a/b/d", ParameterisedItemKey},
+		{"GET", "a/q/c", ParameterisedItemKey},
+	}
+	for _, r := range routes {
+		lpi := pm.MatchPath(srv, strings.Split(r.path, "/"), r.method)
+		IsTrue(t, lpi != nil, fmt.Sprintf("No match for %s %s", r.method, r.path))
+		if lpi != nil {
+			AreEqual(t, r.route, lpi.MapKey(), fmt.Sprintf("Wrong match for %s %s", r.method, r.path))
+		}
+	}
+}
+
+func TestMatchPathNoMatch(t *testing.T) {
+	pm := NewTestRoutingPathMap(t)
+	srv := pm.Services["petstore"]
+	for _, path := range []string{"pet", "pet/1/2", "store/order", "pet/", "users/me/friends"} {
+		lpi := pm.MatchPath(srv, strings.Split(path, "/"), "GET")
+		IsTrue(t, lpi == nil, fmt.Sprintf("Unexpected match for %s", path))
+	}
+}
+
+func TestCheckRequestLogEntryBacktracksToDocumentedPath(t *testing.T) {
+	pm := NewTestRoutingPathMap(t)
+	pm.CheckRequestLogEntry(logs.RequestLogEntry{
+		Method:       "POST",
+		PathElements: []string{"pet", "findByStatus", "uploadImage"},
+		Service:      "petstore",
+		Response:     "200",
+	})
+	v := pm.Services["petstore"].PathItems["pet"].PathItems[ParameterisedItemKey].PathItems["uploadImage"].Verbs["POST"]
+	AreEqual(t, 1, v.Responses["200"].Covered, "Request not matched against the documented path")
+	_, exists := pm.Services["petstore"].PathItems["pet"].PathItems["findByStatus"].PathItems["uploadImage"]
+	IsFalse(t, exists, "Undocumented path added for a documented request")
+}
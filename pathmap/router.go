@@ -0,0 +1,139 @@
+package pathmap
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/go-openapi/spec"
+)
+
+//ServiceRoute describes how requests are routed to a service. A request is routed
+//to the service if its URL matches the host (when one is set) and its path begins
+//with all of the prefix elements
+type ServiceRoute struct {
+	Service string
+	Host    string
+	Prefix  []string
+}
+
+//NewServiceRoute returns the route for the passed service. The route prefix is the
+//service's route path, which may have several elements (e.g. "api/v2/petstore"),
+//followed by the Swagger basePath when the service is configured to use it. A
+//service without a name, route path or host is named after its prefix or host
+func NewServiceRoute(srv ServiceEntry, swgr *spec.Swagger) ServiceRoute {
+	sr := ServiceRoute{
+		Service: srv.ServiceName(),
+		Host:    strings.ToLower(srv.Host),
+		Prefix:  SplitRoutePath(srv.RoutePath),
+	}
+	if srv.UseSwaggerHost && sr.Host == "" && swgr != nil {
+		sr.Host = strings.ToLower(swgr.Host)
+	}
+	if srv.UseBasePath && swgr != nil {
+		sr.Prefix = append(sr.Prefix, SplitRoutePath(swgr.BasePath)...)
+	}
+	//A service routed only by the Swagger host or basePath is named after them
+	if sr.Service == "" {
+		sr.Service = strings.Join(sr.Prefix, "/")
+	}
+	if sr.Service == "" {
+		sr.Service = sr.Host
+	}
+	return sr
+}
+
+//SplitRoutePath splits a route path into its elements ignoring leading and
+//trailing slashes
+func SplitRoutePath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+//Matches returns true if the passed host and path elements are routed to the service
+func (sr ServiceRoute) Matches(host string, elements []string, caseInsensitive bool) bool {
+	if sr.Host != "" && !strings.EqualFold(sr.Host, host) && !strings.EqualFold(sr.Host, hostname(host)) {
+		return false
+	}
+	if len(elements) < len(sr.Prefix) {
+		return false
+	}
+	for i, el := range sr.Prefix {
+		if elements[i] != el && !(caseInsensitive && strings.EqualFold(elements[i], el)) {
+			return false
+		}
+	}
+	return true
+}
+
+//hostname removes the port from the passed host
+func hostname(host string) string {
+	i := strings.LastIndex(host, ":")
+	if i < 0 || strings.HasSuffix(host, "]") {
+		return host
+	}
+	return host[:i]
+}
+
+//AddRoute adds a service route into the PathMap. Routes are kept in order of
+//precedence, a route with a host is matched before one without, then the route
+//with the longest prefix is matched first
+func (pm *PathMap) AddRoute(sr ServiceRoute) {
+	pm.Routes = append(pm.Routes, sr)
+	sort.SliceStable(pm.Routes, func(i, j int) bool {
+		hi, hj := pm.Routes[i].Host != "", pm.Routes[j].Host != ""
+		if hi != hj {
+			return hi
+		}
+		return len(pm.Routes[i].Prefix) > len(pm.Routes[j].Prefix)
+	})
+}
+
+//UnroutedService is the service that log entries are routed to when they match
+//none of the service routes
+const UnroutedService = "unrouted"
+
+//RouteRequestLogEntry sets the service and path elements of the passed log entry
+//using the first service route that matches its host and path. The path is made up
+//of the service and path elements set when the log entry was read. If no route
+//matches, the entry is left as it was read, i.e. with the first element of the path
+//as the service, when there are no routes or that element names a service that was
+//mapped without a route. Otherwise the entry is routed to the UnroutedService, so
+//that a service with a route prefix or host is only credited by requests that match
+func (pm *PathMap) RouteRequestLogEntry(le *logs.RequestLogEntry) {
+	if le.Service == "" {
+		return
+	}
+	host := ""
+	if le.URL != nil {
+		host = le.URL.Host
+	}
+	elements := append([]string{le.Service}, le.PathElements...)
+	for _, sr := range pm.Routes {
+		if sr.Matches(host, elements, pm.CaseInsensitive) {
+			le.Service = sr.Service
+			le.PathElements = elements[len(sr.Prefix):]
+			le.Path = "/" + strings.Join(le.PathElements, "/")
+			return
+		}
+	}
+	if len(pm.Routes) == 0 || (pm.Services[le.Service] != nil && !pm.hasRoute(le.Service)) {
+		return
+	}
+	le.Service = UnroutedService
+	le.PathElements = elements
+	le.Path = "/" + strings.Join(elements, "/")
+}
+
+//hasRoute returns true if there is a route to the named service
+func (pm *PathMap) hasRoute(service string) bool {
+	for _, sr := range pm.Routes {
+		if sr.Service == service {
+			return true
+		}
+	}
+	return false
+}
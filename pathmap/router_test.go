@@ -0,0 +1,133 @@
+package pathmap
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/go-openapi/spec"
+)
+
+func NewTestRoutedEntry(t *testing.T, rawurl string) logs.RequestLogEntry {
+	u, err := url.Parse(rawurl)
+	AssertSuccess(t, err)
+	le := logs.RequestLogEntry{Method: "GET"}
+	err = le.SetURL(u, logs.Normalisation{})
+	AssertSuccess(t, err)
+	return le
+}
+
+func TestServiceNameDefaults(t *testing.T) {
+	AreEqual(t, "pets", ServiceEntry{Name: "pets", RoutePath: "petstore"}.ServiceName(), "Name not used")
+	AreEqual(t, "api/v2/petstore", ServiceEntry{RoutePath: "/api/v2/petstore/"}.ServiceName(), "Route path not used")
+	AreEqual(t, "pets.example.com", ServiceEntry{Host: "pets.example.com"}.ServiceName(), "Host not used")
+}
+
+func TestRouteRequestLogEntryByPrefix(t *testing.T) {
+	pm := NewPathMap()
+	pm.AddRoute(NewServiceRoute(ServiceEntry{RoutePath: "api"}, nil))
+	pm.AddRoute(NewServiceRoute(ServiceEntry{RoutePath: "/api/v2/petstore"}, nil))
+	le := NewTestRoutedEntry(t, "https://127.0.0.1:8081/api/v2/petstore/pet/1")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, "api/v2/petstore", le.Service, "Longest prefix not matched")
+	AreEqual(t, 2, len(le.PathElements), "Wrong number of path elements")
+	AreEqual(t, "pet", le.PathElements[0], "Path element 0 not correct")
+	AreEqual(t, "/pet/1", le.Path, "Path not correct")
+
+	le = NewTestRoutedEntry(t, "https://127.0.0.1:8081/api/v1/user/")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, "api", le.Service, "Shorter prefix not matched")
+	AreEqual(t, 3, len(le.PathElements), "Wrong number of path elements")
+	AreEqual(t, "", le.PathElements[2], "Trailing slash not kept")
+
+	le = NewTestRoutedEntry(t, "https://127.0.0.1:8081/other/pet")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, UnroutedService, le.Service, "Entry routed without a matching route")
+	AreEqual(t, "/other/pet", le.Path, "Path of unrouted entry not correct")
+}
+
+func TestRouteRequestLogEntryByNameOnlyWithoutRoute(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{"/pet":{"get":{"responses":{"200":{"description":"ok"}}}}}}`
+	pm := NewPathMap()
+	swag := MapSwagger(t, pm, "petstore", c)
+	MapSwagger(t, pm, "users", c)
+	pm.AddRoute(NewServiceRoute(ServiceEntry{Name: "petstore", RoutePath: "api/v2/petstore"}, swag))
+
+	le := NewTestRoutedEntry(t, "http://localhost/petstore/pet")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, UnroutedService, le.Service, "Service with a route prefix credited by name")
+	AreEqual(t, "/petstore/pet", le.Path, "Path of unrouted entry not correct")
+
+	le = NewTestRoutedEntry(t, "http://localhost/users/pet")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, "users", le.Service, "Service without a route not routed by name")
+	AreEqual(t, "/pet", le.Path, "Path not correct")
+
+	pm = NewPathMap()
+	le = NewTestRoutedEntry(t, "http://localhost/petstore/pet")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, "petstore", le.Service, "Entry not routed by name without any routes")
+}
+
+func TestServiceNamedAfterSwaggerHostOrBasePath(t *testing.T) {
+	swgr := &spec.Swagger{}
+	swgr.Host = "petstore.swagger.io"
+	swgr.BasePath = "/v2"
+	AreEqual(t, "v2", NewServiceRoute(ServiceEntry{UseBasePath: true}, swgr).Service, "Service not named after the basePath")
+	AreEqual(t, "petstore.swagger.io", NewServiceRoute(ServiceEntry{UseSwaggerHost: true}, swgr).Service, "Service not named after the host")
+	AreEqual(t, "", NewServiceRoute(ServiceEntry{}, swgr).Service, "Service named without a route")
+
+	pm := NewPathMap()
+	err := pm.ReadSwagger([]ServiceEntry{{Swagger: FileURL("PetstoreSwagger.json")}})
+	IsTrue(t, err != nil, "Service without a name not rejected")
+	pm = NewPathMap()
+	err = pm.ReadSwagger([]ServiceEntry{{Name: "pets", Swagger: FileURL("PetstoreSwagger.json")}})
+	IsTrue(t, err != nil, "Service with a name but no route not rejected")
+	pm = NewPathMap()
+	err = pm.ReadSwagger([]ServiceEntry{{UseBasePath: true, Swagger: FileURL("PetstoreSwagger.json")}})
+	AssertSuccess(t, err)
+	_, ok := pm.Services["v2"]
+	IsTrue(t, ok, "Service not named after the basePath of its Swagger file")
+}
+
+func TestRouteRequestLogEntryByHostAndBasePath(t *testing.T) {
+	swgr := &spec.Swagger{}
+	swgr.Host = "petstore.swagger.io"
+	swgr.BasePath = "/v2"
+	pm := NewPathMap()
+	pm.AddRoute(NewServiceRoute(ServiceEntry{RoutePath: "v2"}, nil))
+	pm.AddRoute(NewServiceRoute(ServiceEntry{Name: "petstore", UseSwaggerHost: true, UseBasePath: true}, swgr))
+	pm.AddRoute(NewServiceRoute(ServiceEntry{Host: "users.example.com"}, nil))
+
+	le := NewTestRoutedEntry(t, "https://petstore.swagger.io/v2/pet/1")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, "petstore", le.Service, "Host and basePath not matched")
+	AreEqual(t, "/pet/1", le.Path, "Path not correct")
+
+	le = NewTestRoutedEntry(t, "https://Users.Example.com:443/user/login")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, "users.example.com", le.Service, "Host not matched")
+	AreEqual(t, "/user/login", le.Path, "Path not correct")
+
+	le = NewTestRoutedEntry(t, "https://127.0.0.1/v2/pet/1")
+	pm.RouteRequestLogEntry(&le)
+	AreEqual(t, "v2", le.Service, "Route without host not matched")
+}
+
+func TestCheckRequestLogEntryCaseInsensitive(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{"/pet/findByStatus":{"get":{"responses":{"200":{"description":"ok"}}}}}}`
+	pm := NewPathMap()
+	pm.CaseInsensitive = true
+	swag := MapSwagger(t, pm, "PetStore", c)
+	pm.AddRoute(NewServiceRoute(ServiceEntry{RoutePath: "PetStore"}, swag))
+	u, err := url.Parse("http://localhost/PETSTORE/Pet/FINDBYSTATUS")
+	AssertSuccess(t, err)
+	le := logs.RequestLogEntry{Method: "GET", Response: "200"}
+	err = le.SetURL(u, logs.Normalisation{CaseInsensitive: true})
+	AssertSuccess(t, err)
+	pm.CheckRequestLogEntry(le)
+	AreEqual(t, 1, len(pm.Services), "Request not routed to the service")
+	v := pm.Services["PetStore"].PathItems["pet"].PathItems["findbystatus"].Verbs["GET"]
+	AreEqual(t, 1, v.Responses["200"].Covered, "Request not matched to the documented path")
+}
@@ -0,0 +1,110 @@
+package pathmap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-openapi/spec"
+)
+
+//InferSchema returns a JSON schema describing the passed decoded JSON value. Every
+//property of an object is listed as required, the items of an array are described
+//by the merged schemas of its elements, and whole numbers are integers
+func InferSchema(v interface{}) *spec.Schema {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		s := new(spec.Schema).Typed("object", "")
+		s.Properties = map[string]spec.Schema{}
+		for key, prop := range val {
+			s.Properties[key] = *InferSchema(prop)
+			s.Required = append(s.Required, key)
+		}
+		sort.Strings(s.Required)
+		return s
+	case []interface{}:
+		var items *spec.Schema
+		for _, item := range val {
+			items = MergeSchema(items, InferSchema(item))
+		}
+		if items == nil {
+			items = &spec.Schema{}
+		}
+		return spec.ArrayProperty(items)
+	case string:
+		return spec.StringProperty()
+	case bool:
+		return spec.BoolProperty()
+	case float64:
+		if val == math.Trunc(val) {
+			return new(spec.Schema).Typed("integer", "")
+		}
+		return new(spec.Schema).Typed("number", "")
+	}
+	return &spec.Schema{}
+}
+
+//MergeSchema returns a schema that describes the values of both of the passed
+//inferred schemas. Properties only found in one of the objects are not required,
+//an integer and a number merge to a number, and any other mix of types leaves the
+//type unset
+func MergeSchema(a, b *spec.Schema) *spec.Schema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	at, bt := schemaType(a), schemaType(b)
+	switch {
+	case at == bt:
+	case at == "":
+		return a
+	case bt == "":
+		return b
+	case (at == "integer" && bt == "number") || (at == "number" && bt == "integer"):
+		return new(spec.Schema).Typed("number", "")
+	default:
+		return &spec.Schema{}
+	}
+	switch at {
+	case "object":
+		s := new(spec.Schema).Typed("object", "")
+		s.Properties = map[string]spec.Schema{}
+		for key, prop := range a.Properties {
+			s.Properties[key] = prop
+		}
+		for key, prop := range b.Properties {
+			if existing, exists := s.Properties[key]; exists {
+				prop = *MergeSchema(&existing, &prop)
+			}
+			s.Properties[key] = prop
+		}
+		for _, key := range a.Required {
+			if containsString(b.Required, key) {
+				s.Required = append(s.Required, key)
+			}
+		}
+		return s
+	case "array":
+		return spec.ArrayProperty(MergeSchema(a.Items.Schema, b.Items.Schema))
+	}
+	return a
+}
+
+//schemaType returns the single type of an inferred schema, or "" if it has none
+func schemaType(s *spec.Schema) string {
+	if len(s.Type) == 0 {
+		return ""
+	}
+	return s.Type[0]
+}
+
+//containsString returns true if the slice contains the string
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package pathmap
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+)
+
+func TestInferSchema(t *testing.T) {
+	var v interface{}
+	err := json.Unmarshal([]byte(`{"name":"rex","age":3,"weight":4.5,"tags":["a","b"],"owner":null}`), &v)
+	AssertSuccess(t, err)
+	s := InferSchema(v)
+	AreEqual(t, "object", schemaType(s), "Wrong type for object")
+	AreEqual(t, "age,name,owner,tags,weight", joinStrings(s.Required), "Wrong required properties")
+	AreEqual(t, "string", schemaType(propSchema(s, "name")), "Wrong type for string")
+	AreEqual(t, "integer", schemaType(propSchema(s, "age")), "Wrong type for whole number")
+	AreEqual(t, "number", schemaType(propSchema(s, "weight")), "Wrong type for number")
+	AreEqual(t, "", schemaType(propSchema(s, "owner")), "Wrong type for null")
+	tags := propSchema(s, "tags")
+	AreEqual(t, "array", schemaType(tags), "Wrong type for array")
+	AreEqual(t, "string", schemaType(tags.Items.Schema), "Wrong type for array items")
+}
+
+func TestMergeSchema(t *testing.T) {
+	var a, b interface{}
+	err := json.Unmarshal([]byte(`{"id":1,"name":"rex","price":2}`), &a)
+	AssertSuccess(t, err)
+	err = json.Unmarshal([]byte(`{"id":2,"status":"sold","price":2.5}`), &b)
+	AssertSuccess(t, err)
+	s := MergeSchema(InferSchema(a), InferSchema(b))
+	AreEqual(t, 4, len(s.Properties), "Properties not merged")
+	AreEqual(t, "id,price", joinStrings(s.Required), "Only properties in both should be required")
+	AreEqual(t, "number", schemaType(propSchema(s, "price")), "Integer and number not merged to number")
+	s = MergeSchema(InferSchema("text"), InferSchema(true))
+	AreEqual(t, "", schemaType(s), "Mixed types should have no type")
+	IsTrue(t, MergeSchema(nil, InferSchema("text")) != nil, "Merge with nil should return the other schema")
+}
@@ -0,0 +1,139 @@
+package pathmap
+
+import (
+	"sort"
+
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/go-openapi/spec"
+)
+
+//Security represents the security requirements declared for an operation and
+//records whether the operation has been called both with valid credentials and
+//without them (i.e. the request was rejected with 401 or 403)
+type Security struct {
+	Schemes      []string     `json:"schemes"`
+	Credentials  []Credential `json:"credentials"`
+	Authorized   int          `json:"authorized"`
+	Unauthorized int          `json:"unauthorized"`
+	Unprotected  int          `json:"unprotected"`
+}
+
+//Credential is a location in a request where a credential for one of the
+//operation's security schemes can be passed
+type Credential struct {
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+//NewSecurity returns the Security for the passed operation. The operation's own
+//security requirements override those declared at the top level of the Swagger.
+//If no security is required nil is returned
+func NewSecurity(op *spec.Operation, swgr *spec.Swagger) *Security {
+	reqs := op.Security
+	if reqs == nil && swgr != nil {
+		reqs = swgr.Security
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+	sec := &Security{
+		Schemes:     []string{},
+		Credentials: []Credential{},
+	}
+	seen := map[string]bool{}
+	for _, req := range reqs {
+		for name := range req {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			sec.Schemes = append(sec.Schemes, name)
+		}
+	}
+	sort.Strings(sec.Schemes)
+	cseen := map[Credential]bool{}
+	for _, name := range sec.Schemes {
+		cred := Credential{In: "header", Name: "Authorization"}
+		if swgr != nil {
+			if def, exists := swgr.SecurityDefinitions[name]; exists && def.Type == "apiKey" {
+				cred = Credential{In: def.In, Name: def.Name}
+			}
+		}
+		if !cseen[cred] {
+			cseen[cred] = true
+			sec.Credentials = append(sec.Credentials, cred)
+		}
+	}
+	return sec
+}
+
+//HasCredentials returns true if any of the credentials accepted by the operation
+//are present in the passed request log entry
+func (sec *Security) HasCredentials(le logs.RequestLogEntry) bool {
+	for _, cred := range sec.Credentials {
+		switch cred.In {
+		case "header":
+			if le.Headers.Get(cred.Name) != "" {
+				return true
+			}
+		case "query":
+			if _, exists := le.Query[cred.Name]; exists {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//CheckRequestLogEntry records the use of the operation's security by the passed
+//request log entry. A 401 or 403 response is counted as an unauthorized call. Any
+//other response is counted as an authorized call if the request carried credentials,
+//or if the log does not record headers. An accepted request logged without any
+//credentials is counted as unprotected
+func (sec *Security) CheckRequestLogEntry(le logs.RequestLogEntry) {
+	if le.Response == "401" || le.Response == "403" {
+		sec.Unauthorized = sec.Unauthorized + 1
+		return
+	}
+	if le.Headers == nil || sec.HasCredentials(le) {
+		sec.Authorized = sec.Authorized + 1
+		return
+	}
+	sec.Unprotected = sec.Unprotected + 1
+}
+
+//APIKeyCredentials returns the locations of the credentials of the apiKey security
+//schemes defined in the Swagger, sorted by name
+func APIKeyCredentials(swgr *spec.Swagger) []Credential {
+	creds := []Credential{}
+	if swgr == nil {
+		return creds
+	}
+	for _, def := range swgr.SecurityDefinitions {
+		if def.Type == "apiKey" && def.Name != "" {
+			creds = append(creds, Credential{In: def.In, Name: def.Name})
+		}
+	}
+	sort.Slice(creds, func(i, j int) bool {
+		if creds[i].Name == creds[j].Name {
+			return creds[i].In < creds[j].In
+		}
+		return creds[i].Name < creds[j].Name
+	})
+	return creds
+}
+
+//Redaction returns a Redaction of the CredentialHeaders and of the apiKey
+//credentials of every service in the PathMap
+func (pm *PathMap) Redaction() logs.Redaction {
+	r := logs.NewRedaction()
+	for _, cred := range pm.Credentials {
+		switch cred.In {
+		case "header":
+			r.Headers = append(r.Headers, cred.Name)
+		case "query":
+			r.Query = append(r.Query, cred.Name)
+		}
+	}
+	return r
+}
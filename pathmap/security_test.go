@@ -0,0 +1,61 @@
+package pathmap
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/go-openapi/spec"
+)
+
+func NewTestSecuredSwagger() *spec.Swagger {
+	swgr := &spec.Swagger{}
+	swgr.SecurityDefinitions = spec.SecurityDefinitions{
+		"api_key": spec.APIKeyAuth("api_key", "header"),
+		"token":   spec.APIKeyAuth("token", "query"),
+		"oauth":   spec.OAuth2Implicit("https://auth.example.com"),
+	}
+	swgr.Security = []map[string][]string{{"oauth": {"read"}}}
+	return swgr
+}
+
+func TestNewSecurityUsesTopLevelRequirements(t *testing.T) {
+	sec := NewSecurity(&spec.Operation{}, NewTestSecuredSwagger())
+	IsTrue(t, sec != nil, "Expected top level security to apply")
+	AreEqual(t, 1, len(sec.Schemes), "Wrong number of schemes")
+	AreEqual(t, "oauth", sec.Schemes[0], "Wrong scheme")
+	AreEqual(t, Credential{In: "header", Name: "Authorization"}, sec.Credentials[0], "Wrong credential")
+}
+
+func TestNewSecurityOperationOverridesTopLevel(t *testing.T) {
+	op := &spec.Operation{}
+	op.Security = []map[string][]string{}
+	sec := NewSecurity(op, NewTestSecuredSwagger())
+	IsTrue(t, sec == nil, "Expected empty operation security to override top level")
+
+	op.Security = []map[string][]string{{"api_key": {}}, {"token": {}}}
+	sec = NewSecurity(op, NewTestSecuredSwagger())
+	AreEqual(t, 2, len(sec.Credentials), "Wrong number of credentials")
+	AreEqual(t, Credential{In: "header", Name: "api_key"}, sec.Credentials[0], "Wrong credential")
+	AreEqual(t, Credential{In: "query", Name: "token"}, sec.Credentials[1], "Wrong credential")
+}
+
+func TestSecurityCheckRequestLogEntry(t *testing.T) {
+	op := &spec.Operation{}
+	op.Security = []map[string][]string{{"api_key": {}}, {"token": {}}}
+	sec := NewSecurity(op, NewTestSecuredSwagger())
+
+	sec.CheckRequestLogEntry(logs.RequestLogEntry{Response: "200", Headers: http.Header{"Api_key": {"abc"}}})
+	sec.CheckRequestLogEntry(logs.RequestLogEntry{Response: "200", Headers: http.Header{}, Query: url.Values{"token": {"abc"}}})
+	sec.CheckRequestLogEntry(logs.RequestLogEntry{Response: "200"})
+	AreEqual(t, 3, sec.Authorized, "Wrong authorized count")
+
+	sec.CheckRequestLogEntry(logs.RequestLogEntry{Response: "401", Headers: http.Header{}})
+	sec.CheckRequestLogEntry(logs.RequestLogEntry{Response: "403", Headers: http.Header{"Api_key": {"abc"}}})
+	AreEqual(t, 2, sec.Unauthorized, "Wrong unauthorized count")
+
+	sec.CheckRequestLogEntry(logs.RequestLogEntry{Response: "200", Headers: http.Header{}})
+	AreEqual(t, 1, sec.Unprotected, "Wrong unprotected count")
+}
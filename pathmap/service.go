@@ -0,0 +1,28 @@
+package pathmap
+
+import "strings"
+
+//ServiceEntry contains the path name used by the reverse proxy to route to the
+//service and the URI of the swagger.json file to use for that service.
+//The route path may have several elements (e.g. "api/v2/petstore"), and requests
+//can also be routed by host and by the basePath in the swagger.json file
+type ServiceEntry struct {
+	Name           string `json:"name"`
+	RoutePath      string `json:"routePath"`
+	Host           string `json:"host"`
+	UseSwaggerHost bool   `json:"useSwaggerHost"`
+	UseBasePath    bool   `json:"useBasePath"`
+	Swagger        string `json:"swagger"`
+}
+
+//ServiceName returns the name the service is reported under. This is the name if
+//one is set, otherwise the route path, otherwise the host
+func (se ServiceEntry) ServiceName() string {
+	if se.Name != "" {
+		return se.Name
+	}
+	if rp := strings.Trim(se.RoutePath, "/"); rp != "" {
+		return rp
+	}
+	return se.Host
+}
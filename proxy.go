@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/codeafix/apicovchk/coverage"
+	"github.com/codeafix/apicovchk/recorder"
+)
+
+type proxyOptions struct {
+	listen   string
+	upstream string
+	log      string
+}
+
+func parseProxyCommandLineOptions(args []string) (success bool, conf coverage.Config, outfilename string, po proxyOptions) {
+	po = proxyOptions{listen: "localhost:8080"}
+	rest := []string{args[0]}
+	for i := 2; i < len(args); i++ {
+		switch args[i] {
+		case "-listen", "-upstream", "-log":
+			if i+1 >= len(args) {
+				fmt.Printf("Value missing after %s option \n\n", args[i])
+				return false, conf, outfilename, po
+			}
+			switch args[i] {
+			case "-listen":
+				po.listen = args[i+1]
+			case "-upstream":
+				po.upstream = args[i+1]
+			case "-log":
+				po.log = args[i+1]
+			}
+			i++
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	success, conf, outfilename = parseCommandLineOptions(rest)
+	if success && po.upstream == "" {
+		fmt.Printf("<upstreamURL> missing, the proxy needs an -upstream option \n\n")
+		success = false
+	}
+	return success, conf, outfilename, po
+}
+
+func newProxyHandler(upstream *url.URL, rec *recorder.Recorder) http.Handler {
+	rp := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(upstream)
+			pr.SetXForwarded()
+		},
+	}
+	h := recorder.NewHandler(rec, rp)
+	//Record the requests with the upstream host, so that they are routed to the
+	//services configured with that host rather than the address of the proxy
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.URL.Scheme = upstream.Scheme
+		req.URL.Host = upstream.Host
+		h.ServeHTTP(w, req)
+	})
+}
+
+func proxy(conf coverage.Config, outfilename string, po proxyOptions, stop <-chan os.Signal) error {
+	upstream, err := url.Parse(po.upstream)
+	if err != nil {
+		return err
+	}
+	if upstream.Scheme == "" || upstream.Host == "" {
+		return fmt.Errorf("Upstream URL '%s' must include the scheme and host", po.upstream)
+	}
+	ln, err := net.Listen("tcp", po.listen)
+	if err != nil {
+		return err
+	}
+	rec := recorder.NewRecorder()
+	rec.Normalisation = conf.Normalise
+	err = rec.RedactAPIKeys(conf.Services)
+	if err != nil {
+		ln.Close()
+		return err
+	}
+	srv := &http.Server{Handler: newProxyHandler(upstream, rec)}
+	fmt.Printf("Recording requests to %s on http://%s, stop the proxy to write the coverage report\n", upstream, ln.Addr())
+	err = serveUntilStopped(srv, ln, stop)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d requests recorded\n", len(rec.Entries()))
+	if po.log != "" {
+		err = rec.WriteTransactionLogFile(po.log)
+		if err != nil {
+			return err
+		}
+	}
+	cc, err := rec.Coverage(conf)
+	if err != nil {
+		return err
+	}
+	return writeReport(conf, cc, outfilename)
+}
+
+func serveUntilStopped(srv *http.Server, ln net.Listener, stop <-chan os.Signal) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(ln)
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-stop:
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/recorder"
+)
+
+func TestParseProxyCommandOptions(t *testing.T) {
+	args := []string{"apicovchk.exe", "proxy", "-opt", "options.json", "-upstream", "http://localhost:9000", "-log", "proxy.log", "-out", "proxy.html"}
+	success, conf, outfilename, po := parseProxyCommandLineOptions(args)
+	IsTrue(t, success, "Expected parse to succeed")
+	AreEqual(t, "proxy.html", outfilename, "outfilename wrong")
+	AreEqual(t, 2, len(conf.Services), "Options file not read")
+	AreEqual(t, "localhost:8080", po.listen, "listen not set to default")
+	AreEqual(t, "http://localhost:9000", po.upstream, "upstream wrong")
+	AreEqual(t, "proxy.log", po.log, "log wrong")
+
+	args = []string{"apicovchk.exe", "proxy", "-listen", ":9090", "-opt", "options.json", "-upstream", "http://localhost:9000"}
+	success, _, _, po = parseProxyCommandLineOptions(args)
+	IsTrue(t, success, "Expected parse to succeed")
+	AreEqual(t, ":9090", po.listen, "listen wrong")
+}
+
+func TestParseProxyCommandOptionsFailsWithoutUpstream(t *testing.T) {
+	args := []string{"apicovchk.exe", "proxy", "-opt", "options.json"}
+	success, _, _, _ := parseProxyCommandLineOptions(args)
+	IsFalse(t, success, "Expected parse to fail")
+}
+
+func TestParseProxyCommandOptionsFailsWhenNoValue(t *testing.T) {
+	args := []string{"apicovchk.exe", "proxy", "-opt", "options.json", "-upstream"}
+	success, _, _, _ := parseProxyCommandLineOptions(args)
+	IsFalse(t, success, "Expected parse to fail")
+}
+
+func TestProxyHandlerForwardsAndRecords(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"path":"` + req.URL.Path + `","body":"` + string(body) + `"}`))
+	}))
+	defer upstream.Close()
+	u, err := url.Parse(upstream.URL)
+	AssertSuccess(t, err)
+	rec := recorder.NewRecorder()
+	srv := httptest.NewServer(newProxyHandler(u, rec))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/petstore/pet?debug=true", "text/plain", strings.NewReader("rex"))
+	AssertSuccess(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	AssertSuccess(t, err)
+	AreEqual(t, http.StatusCreated, resp.StatusCode, "Upstream response code not returned")
+	AreEqual(t, `{"path":"/petstore/pet","body":"rex"}`, string(body), "Request not forwarded upstream")
+
+	entries := rec.Entries()
+	AreEqual(t, 1, len(entries), "Wrong number of entries recorded")
+	AreEqual(t, "POST", entries[0].Method, "Method not recorded")
+	AreEqual(t, "petstore", entries[0].Service, "Service not recorded")
+	AreEqual(t, "/pet", entries[0].Path, "Path not recorded")
+	AreEqual(t, "true", entries[0].Query.Get("debug"), "Query not recorded")
+	AreEqual(t, "201", entries[0].Response, "Response not recorded")
+	AreEqual(t, u.Host, entries[0].URL.Host, "Upstream host not recorded")
+}
+
+func TestServeUntilStopped(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	AssertSuccess(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})}
+	stop := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- serveUntilStopped(srv, ln, stop)
+	}()
+	resp, err := http.Get("http://" + ln.Addr().String() + "/petstore/pet")
+	AssertSuccess(t, err)
+	resp.Body.Close()
+	stop <- syscall.SIGTERM
+	AssertSuccess(t, <-done)
+	_, err = http.Get("http://" + ln.Addr().String() + "/petstore/pet")
+	IsTrue(t, err != nil, "Proxy still serving after it was stopped")
+}
+
+func TestProxyWritesReport(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer upstream.Close()
+	success, conf, _, po := parseProxyCommandLineOptions([]string{"apicovchk.exe", "proxy", "-opt", "options.json",
+		"-upstream", upstream.URL, "-listen", "127.0.0.1:0", "-log", TempPath("proxy.log")})
+	IsTrue(t, success, "Expected parse to succeed")
+	conf.Services = conf.Services[:1]
+	conf.Services[0].Swagger = FileURL("PetstoreSwagger.json")
+	conf.TransactionLogs = nil
+	stop := make(chan os.Signal, 1)
+	stop <- syscall.SIGTERM
+	err := proxy(conf, TempPath("proxy.html"), po, stop)
+	AssertSuccess(t, err)
+	_, err = os.Stat(TempPath("proxy.html"))
+	AssertSuccess(t, err)
+	_, err = os.Stat(TempPath("proxy.log"))
+	AssertSuccess(t, err)
+
+	po.upstream = "localhost:9000"
+	err = proxy(conf, TempPath("proxy.html"), po, stop)
+	IsTrue(t, err != nil, "Upstream without a scheme not rejected")
+}
+
+func TestProxyLogHasNoCredentials(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer upstream.Close()
+	success, conf, _, po := parseProxyCommandLineOptions([]string{"apicovchk.exe", "proxy", "-opt", "options.json",
+		"-upstream", upstream.URL, "-listen", "127.0.0.1:0", "-log", TempPath("proxy_credentials.log")})
+	IsTrue(t, success, "Expected parse to succeed")
+	conf.Services = conf.Services[:1]
+	conf.Services[0].Swagger = FileURL("PetstoreSwagger.json")
+	conf.TransactionLogs = nil
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	AssertSuccess(t, err)
+	po.listen = ln.Addr().String()
+	ln.Close()
+	stop := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- proxy(conf, TempPath("proxy_credentials.html"), po, stop)
+	}()
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		req, err := http.NewRequest("GET", "http://"+po.listen+"/petstore/store/inventory", nil)
+		AssertSuccess(t, err)
+		req.Header.Set("Authorization", "Bearer s3cr3t-token")
+		req.Header.Set("api_key", "s3cr3t-key")
+		resp, err = http.DefaultClient.Do(req)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	IsTrue(t, resp != nil, "Proxy not listening")
+	resp.Body.Close()
+	stop <- syscall.SIGTERM
+	AssertSuccess(t, <-done)
+
+	c, err := os.ReadFile(TempPath("proxy_credentials.log"))
+	AssertSuccess(t, err)
+	IsTrue(t, strings.Contains(string(c), "/petstore/store/inventory"), "Request not written to the log")
+	IsTrue(t, strings.Contains(string(c), "Authorization: <redacted>"), "Authorization header not redacted")
+	IsFalse(t, strings.Contains(string(c), "s3cr3t"), "Credentials written to the log")
+}
@@ -0,0 +1,122 @@
+package recorder
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/codeafix/apicovchk/coverage"
+	"github.com/codeafix/apicovchk/report"
+)
+
+//Handler is server middleware that records each request handled by the Next
+//handler, and the response it wrote, in the Recorder
+type Handler struct {
+	Recorder *Recorder
+	Next     http.Handler
+}
+
+//NewHandler returns a Handler that records the requests handled by the next
+//handler in the passed Recorder
+func NewHandler(r *Recorder, next http.Handler) *Handler {
+	return &Handler{
+		Recorder: r,
+		Next:     next,
+	}
+}
+
+//ServeHTTP passes the request on to the next handler and records it. The request
+//body is read before the request is handled, and replaced so that the handler
+//can read it. The response body is recorded when it is JSON
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err}))
+	}
+	rw := &responseRecorder{ResponseWriter: w}
+	start := time.Now()
+	h.Next.ServeHTTP(rw, req)
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	tle, err := NewEntry(req, body, rw.status, rw.Header(), rw.body.Bytes(), start, time.Now(), h.Recorder.Normalisation)
+	//Skip requests to URLs without a path, which can't be routed to a service
+	if err == nil {
+		h.Recorder.Record(tle)
+	}
+}
+
+//responseRecorder passes the response on to the ResponseWriter, keeping the status
+//code and, when the response is JSON, a copy of the body
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	json   bool
+	body   bytes.Buffer
+}
+
+//WriteHeader records the status code of the response
+func (rr *responseRecorder) WriteHeader(status int) {
+	if rr.status == 0 && status >= http.StatusOK {
+		rr.status = status
+		rr.json = IsJSON(rr.Header())
+	}
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+//Write records the body of a JSON response
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.WriteHeader(http.StatusOK)
+	}
+	if rr.json {
+		rr.body.Write(b)
+	}
+	return rr.ResponseWriter.Write(b)
+}
+
+//Flush sends any buffered data to the client if the ResponseWriter supports it
+func (rr *responseRecorder) Flush() {
+	if f, ok := rr.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+//Unwrap returns the wrapped ResponseWriter for use by an http.ResponseController
+func (rr *responseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
+
+//LogHandler returns a handler that responds with the recorded entries in the
+//transaction log format. It can be mounted on an admin endpoint of the service,
+//outside of the handler whose requests are recorded. Credentials are redacted
+//as the entries are recorded, but the log still holds the URLs, parameters and
+//bodies of every request, so the endpoint must not be exposed outside test
+//environments
+func (r *Recorder) LogHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/tab-separated-values; charset=utf-8")
+		r.WriteTransactionLog(w)
+	})
+}
+
+//ReportHandler returns a handler that responds with the HTML coverage report of
+//the recorded entries, calculated with the passed configuration. The Swagger files
+//of the configured services are read for every report
+func (r *Recorder) ReportHandler(config coverage.Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cc, err := r.Coverage(config)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		hw := report.NewHTMLWriter(cc)
+		hw.Generate()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(hw.Buffer.Bytes())
+	})
+}
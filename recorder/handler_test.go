@@ -0,0 +1,111 @@
+package recorder
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeafix/apicovchk/coverage"
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+func NewTestHandler(r *Recorder) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/petstore/pet", func(w http.ResponseWriter, req *http.Request) {
+		pet := map[string]interface{}{}
+		err := json.NewDecoder(req.Body).Decode(&pet)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(pet)
+	})
+	mux.HandleFunc("/petstore/store/inventory", func(w http.ResponseWriter, req *http.Request) {})
+	return NewHandler(r, mux)
+}
+
+func TestHandlerRecordsRequests(t *testing.T) {
+	r := NewRecorder()
+	h := NewTestHandler(r)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/petstore/pet", strings.NewReader(`{"name":"rex"}`))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(w, req)
+	AreEqual(t, http.StatusCreated, w.Code, "Request body not passed to the handler")
+	AreEqual(t, `{"name":"rex"}`, strings.TrimSpace(w.Body.String()), "Response not passed on")
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/petstore/store/inventory?debug=true", nil))
+	AreEqual(t, http.StatusOK, w.Code, "Wrong default status")
+
+	entries := r.Entries()
+	AreEqual(t, 2, len(entries), "Wrong number of entries recorded")
+	AreEqual(t, "POST", entries[0].Method, "Method not recorded")
+	AreEqual(t, "/pet", entries[0].Path, "Path not recorded")
+	AreEqual(t, "201", entries[0].Response, "Response not recorded")
+	AreEqual(t, "application/json", entries[0].Headers.Get("Content-Type"), "Headers not recorded")
+	body, ok := entries[0].JSONBody.(map[string]interface{})
+	IsTrue(t, ok && body["name"] == "rex", "Request body not recorded")
+	resp, ok := entries[0].ResponseBody.(map[string]interface{})
+	IsTrue(t, ok && resp["name"] == "rex", "Response body not recorded")
+	AreEqual(t, "/store/inventory", entries[1].Path, "Path not recorded")
+	AreEqual(t, "true", entries[1].Query.Get("debug"), "Query not recorded")
+	AreEqual(t, "200", entries[1].Response, "Default response not recorded")
+	IsTrue(t, entries[1].ResponseBody == nil, "Empty response body recorded")
+}
+
+func TestLogHandler(t *testing.T) {
+	r := NewRecorder()
+	h := NewTestHandler(r)
+	req := httptest.NewRequest("GET", "/petstore/store/inventory", nil)
+	req.Header.Set("Authorization", "Bearer s3cr3t-token")
+	req.Header.Set("Cookie", "session=s3cr3t-session")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	srv := httptest.NewServer(r.LogHandler())
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	AssertSuccess(t, err)
+	defer resp.Body.Close()
+	c, err := io.ReadAll(resp.Body)
+	AssertSuccess(t, err)
+	lines := strings.Split(strings.TrimSpace(string(c)), "\n")
+	AreEqual(t, 2, len(lines), "Wrong number of lines in the log")
+	AreEqual(t, strings.TrimSpace(logs.TransactionLogHeader), lines[0], "Log header not written")
+	IsTrue(t, strings.Contains(lines[1], "\tGET\thttp://example.com/petstore/store/inventory\t"), "Request not written to the log")
+	IsTrue(t, strings.Contains(lines[1], "Authorization: "+logs.RedactedValue), "Authorization header not redacted")
+	IsFalse(t, strings.Contains(lines[1], "s3cr3t"), "Credentials served by the log handler")
+	AreEqual(t, 1, len(r.Entries()), "Log request recorded")
+}
+
+func TestReportHandler(t *testing.T) {
+	r := NewRecorder()
+	h := NewTestHandler(r)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/petstore/store/inventory", nil))
+	config := coverage.Config{
+		Services: []pathmap.ServiceEntry{{RoutePath: "petstore", Swagger: FileURL("PetstoreSwagger.json")}},
+	}
+	cc, err := r.Coverage(config)
+	AssertSuccess(t, err)
+	AreEqual(t, 1, cc.OperationsCovered, "Recorded request not covered")
+	err = r.WriteReport(config, TempPath("recorded.html"))
+	AssertSuccess(t, err)
+
+	w := httptest.NewRecorder()
+	r.ReportHandler(config).ServeHTTP(w, httptest.NewRequest("GET", "/coverage", nil))
+	AreEqual(t, http.StatusOK, w.Code, "Report not returned")
+	IsTrue(t, strings.Contains(w.Body.String(), "/store/inventory"), "Report does not list the endpoint")
+
+	config.Services[0].Swagger = FileURL("DoesntExist.json")
+	w = httptest.NewRecorder()
+	r.ReportHandler(config).ServeHTTP(w, httptest.NewRequest("GET", "/coverage", nil))
+	AreEqual(t, http.StatusInternalServerError, w.Code, "Error reading the Swagger not returned")
+}
@@ -0,0 +1,186 @@
+//Package recorder records the HTTP requests made or handled in a test as log
+//entries, so that the coverage of an API can be measured without any external
+//logging. The recorded entries can be written as a transaction log, checked
+//against a PathMap, or added to a Coverage Checker
+package recorder
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeafix/apicovchk/coverage"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/pathmap"
+	"github.com/codeafix/apicovchk/report"
+)
+
+//TimeFormat is the format of the start and end times of the recorded entries
+const TimeFormat = "15:04:05.000"
+
+//Recorder collects the recorded requests. It is safe for concurrent use. The
+//Normalisation is applied to the path of each request as it is recorded, and the
+//credentials listed in the Redaction are replaced so they are never kept
+type Recorder struct {
+	Normalisation logs.Normalisation
+	Redaction     logs.Redaction
+	mutex         sync.Mutex
+	entries       []logs.TransactionLogEntry
+}
+
+//NewRecorder returns a new instance of the Recorder, which redacts the
+//CredentialHeaders of the recorded requests
+func NewRecorder() *Recorder {
+	return &Recorder{
+		Redaction: logs.NewRedaction(),
+		entries:   []logs.TransactionLogEntry{},
+	}
+}
+
+//RedactAPIKeys reads the Swagger files of the passed services and adds the
+//headers and query parameters of their apiKey security schemes to the Redaction
+func (r *Recorder) RedactAPIKeys(services []pathmap.ServiceEntry) error {
+	pm := pathmap.NewPathMap()
+	err := pm.ReadSwagger(services)
+	if err != nil {
+		return err
+	}
+	keys := pm.Redaction()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.Redaction.Headers = append(r.Redaction.Headers, keys.Headers...)
+	r.Redaction.Query = append(r.Redaction.Query, keys.Query...)
+	return nil
+}
+
+//NewEntry returns the log entry for a request and the response it received. The
+//request body and the JSON response body are recorded when they are passed, and a
+//request without a method is recorded as a GET, as it is sent by the client. An
+//error is returned if the path of the request URL has no elements
+func NewEntry(req *http.Request, body []byte, status int, respHeader http.Header, respBody []byte, start, end time.Time, n logs.Normalisation) (logs.TransactionLogEntry, error) {
+	tle := logs.TransactionLogEntry{
+		Duration: int(end.Sub(start).Milliseconds()),
+		Start:    start.Format(TimeFormat),
+		End:      end.Format(TimeFormat),
+		Body:     string(body),
+	}
+	u := *req.URL
+	if u.Host == "" {
+		u.Host = req.Host
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		if req.TLS != nil {
+			u.Scheme = "https"
+		}
+	}
+	err := tle.SetURL(&u, n)
+	if err != nil {
+		return tle, err
+	}
+	tle.Method = req.Method
+	if tle.Method == "" {
+		tle.Method = http.MethodGet
+	}
+	tle.Query = u.Query()
+	tle.Headers = req.Header.Clone()
+	tle.Form = logs.ParseFormBody(tle.Body)
+	tle.JSONBody = logs.ParseJSONBody(tle.Body)
+	tle.Response = strconv.Itoa(status)
+	if IsJSON(respHeader) {
+		tle.ResponseBody = logs.ParseJSONBody(string(respBody))
+	}
+	return tle, nil
+}
+
+//IsJSON returns true if the Content-Type header is a JSON media type
+func IsJSON(h http.Header) bool {
+	mt, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+//Record redacts the credentials of the entry and adds it to the recorded entries
+func (r *Recorder) Record(tle logs.TransactionLogEntry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.Redaction.Redact(&tle.RequestLogEntry)
+	r.entries = append(r.entries, tle)
+}
+
+//Entries returns a copy of the recorded entries in the order they were recorded
+func (r *Recorder) Entries() []logs.TransactionLogEntry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	entries := make([]logs.TransactionLogEntry, len(r.entries))
+	copy(entries, r.entries)
+	return entries
+}
+
+//RequestLogEntries returns the recorded entries as request log entries, which can
+//be passed to the AddEntries of a Coverage Checker
+func (r *Recorder) RequestLogEntries() []logs.RequestLogEntry {
+	lel := []logs.RequestLogEntry{}
+	for _, tle := range r.Entries() {
+		lel = append(lel, tle.RequestLogEntry)
+	}
+	return lel
+}
+
+//Reset removes all of the recorded entries
+func (r *Recorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries = []logs.TransactionLogEntry{}
+}
+
+//CheckPathMap checks each of the recorded entries against the PathMap
+func (r *Recorder) CheckPathMap(pm *pathmap.PathMap) {
+	for _, le := range r.RequestLogEntries() {
+		pm.CheckRequestLogEntry(le)
+	}
+}
+
+//WriteTransactionLog writes the recorded entries to w in the transaction log format
+func (r *Recorder) WriteTransactionLog(w io.Writer) error {
+	return logs.WriteTransactionLog(w, r.Entries())
+}
+
+//WriteTransactionLogFile writes the recorded entries to the named file in the
+//transaction log format
+func (r *Recorder) WriteTransactionLogFile(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	err = r.WriteTransactionLog(f)
+	if err != nil {
+		return fmt.Errorf("Error writing transaction log '%s': %s", filename, err.Error())
+	}
+	return nil
+}
+
+//Coverage calculates the coverage of the recorded entries with the passed
+//configuration. The log files in the configuration are also read
+func (r *Recorder) Coverage(config coverage.Config) (*coverage.CovCheckerInfo, error) {
+	return coverage.New(config).AddLogs().AddEntries(r.RequestLogEntries()...).Result()
+}
+
+//WriteReport writes the HTML coverage report of the recorded entries, calculated
+//with the passed configuration, to the named file
+func (r *Recorder) WriteReport(config coverage.Config, filename string) error {
+	cc, err := r.Coverage(config)
+	if err != nil {
+		return err
+	}
+	return report.NewHTMLWriter(cc).Write(filename)
+}
@@ -0,0 +1,138 @@
+package recorder
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeafix/apicovchk/coverage"
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/logs"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+func NewTestPathMap(t *testing.T) *pathmap.PathMap {
+	c := `{"swagger":"2.0","paths":{
+		"/pet/findByStatus":{"get":{"parameters":[{"name":"status","in":"query","type":"string"}],
+			"responses":{"200":{"description":"ok"},"400":{"description":"invalid status"}}}},
+		"/pet":{"post":{"consumes":["application/x-www-form-urlencoded"],
+			"parameters":[{"name":"name","in":"formData","type":"string"}],
+			"responses":{"201":{"description":"created"}}}}
+	}}`
+	pm := pathmap.NewPathMap()
+	MapSwagger(t, pm, "petstore", c)
+	return pm
+}
+
+func TestNewEntry(t *testing.T) {
+	req := httptest.NewRequest("POST", "/petstore/pet?debug=true", strings.NewReader("name=rex"))
+	req.Header.Set("Authorization", "Bearer abc")
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	respHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	tle, err := NewEntry(req, []byte("name=rex"), 201, respHeader, []byte(`{"id":1}`), start, start.Add(25*time.Millisecond), logs.Normalisation{})
+	AssertSuccess(t, err)
+	AreEqual(t, 25, tle.Duration, "Duration not correct")
+	AreEqual(t, "10:00:00.000", tle.Start, "Start not correct")
+	AreEqual(t, "10:00:00.025", tle.End, "End not correct")
+	AreEqual(t, "POST", tle.Method, "Method not correct")
+	AreEqual(t, "http://example.com/petstore/pet?debug=true", tle.URL.String(), "URL not correct")
+	AreEqual(t, "petstore", tle.Service, "Service not correct")
+	AreEqual(t, "/pet", tle.Path, "Path not correct")
+	AreEqual(t, "true", tle.Query.Get("debug"), "Query not correct")
+	AreEqual(t, "rex", tle.Form.Get("name"), "Form not correct")
+	AreEqual(t, "Bearer abc", tle.Headers.Get("Authorization"), "Headers not correct")
+	AreEqual(t, "201", tle.Response, "Response not correct")
+	body, ok := tle.ResponseBody.(map[string]interface{})
+	IsTrue(t, ok && body["id"] == float64(1), "Response body not correct")
+
+	req = httptest.NewRequest("GET", "/", nil)
+	_, err = NewEntry(req, nil, 200, http.Header{}, nil, start, start, logs.Normalisation{})
+	IsTrue(t, err != nil, "Request without a path not rejected")
+}
+
+func TestIsJSON(t *testing.T) {
+	IsTrue(t, IsJSON(http.Header{"Content-Type": {"application/json"}}), "application/json not JSON")
+	IsTrue(t, IsJSON(http.Header{"Content-Type": {"application/problem+json"}}), "application/problem+json not JSON")
+	IsFalse(t, IsJSON(http.Header{"Content-Type": {"text/plain"}}), "text/plain is JSON")
+	IsFalse(t, IsJSON(http.Header{}), "Missing content type is JSON")
+}
+
+func TestRecorderWritesTransactionLog(t *testing.T) {
+	r := NewRecorder()
+	start := time.Now()
+	for _, target := range []string{"/petstore/pet/findByStatus?status=sold", "/petstore/pet/findByStatus?status=none"} {
+		tle, err := NewEntry(httptest.NewRequest("GET", target, nil), nil, 200, http.Header{}, nil, start, start, r.Normalisation)
+		AssertSuccess(t, err)
+		r.Record(tle)
+	}
+	AreEqual(t, 2, len(r.Entries()), "Wrong number of entries recorded")
+
+	var buf bytes.Buffer
+	err := r.WriteTransactionLog(&buf)
+	AssertSuccess(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	AreEqual(t, 3, len(lines), "Wrong number of lines in the log")
+	tlr := &logs.TransactionLogInfo{}
+	tle, err := tlr.ParseTransactionLogEntry(lines[2])
+	AssertSuccess(t, err)
+	AreEqual(t, "none", tle.Query.Get("status"), "Entry not written in order")
+
+	r.Reset()
+	AreEqual(t, 0, len(r.Entries()), "Entries not reset")
+}
+
+func TestRecorderChecksPathMap(t *testing.T) {
+	r := NewRecorder()
+	start := time.Now()
+	tle, err := NewEntry(httptest.NewRequest("GET", "/petstore/pet/findByStatus?status=sold", nil), nil, 400, http.Header{}, nil, start, start, r.Normalisation)
+	AssertSuccess(t, err)
+	r.Record(tle)
+	pm := NewTestPathMap(t)
+	r.CheckPathMap(pm)
+	v := pm.Services["petstore"].PathItems["pet"].PathItems["findByStatus"].Verbs["GET"]
+	AreEqual(t, 1, v.Responses["400"].Covered, "Response not covered")
+	AreEqual(t, 1, v.QueryParameters["status"].Covered, "Query parameter not covered")
+}
+
+func TestRecorderRedactsCredentials(t *testing.T) {
+	c := `{"swagger":"2.0",
+		"securityDefinitions":{"header_key":{"type":"apiKey","in":"header","name":"X-Api-Key"},"query_key":{"type":"apiKey","in":"query","name":"api_key"}},
+		"security":[{"header_key":[]}],
+		"paths":{"/pet":{"get":{"responses":{"200":{"description":"ok"}}}}}}`
+	err := os.MkdirAll(RootPath("temp"), 0755)
+	AssertSuccess(t, err)
+	err = os.WriteFile(TempPath("apikeys.json"), []byte(c), 0644)
+	AssertSuccess(t, err)
+	services := []pathmap.ServiceEntry{{RoutePath: "petstore", Swagger: FileURL("temp/apikeys.json")}}
+	r := NewRecorder()
+	err = r.RedactAPIKeys(services)
+	AssertSuccess(t, err)
+
+	req := httptest.NewRequest("GET", "/petstore/pet?api_key=s3cr3t-query", nil)
+	req.Header.Set("Authorization", "Bearer s3cr3t-token")
+	req.Header.Set("Cookie", "session=s3cr3t-cookie")
+	req.Header.Set("X-Api-Key", "s3cr3t-key")
+	start := time.Now()
+	tle, err := NewEntry(req, nil, 200, http.Header{}, nil, start, start, r.Normalisation)
+	AssertSuccess(t, err)
+	r.Record(tle)
+
+	var buf bytes.Buffer
+	err = r.WriteTransactionLog(&buf)
+	AssertSuccess(t, err)
+	IsFalse(t, strings.Contains(buf.String(), "s3cr3t"), "Credentials written to the log")
+	err = r.WriteTransactionLogFile(TempPath("redacted.log"))
+	AssertSuccess(t, err)
+	b, err := os.ReadFile(TempPath("redacted.log"))
+	AssertSuccess(t, err)
+	IsFalse(t, strings.Contains(string(b), "s3cr3t"), "Credentials written to the log file")
+
+	cc, err := coverage.New(coverage.Config{Services: services}).AddEntries(r.RequestLogEntries()...).Result()
+	AssertSuccess(t, err)
+	v := cc.PathMap.Services["petstore"].PathItems["pet"].Verbs["GET"]
+	AreEqual(t, 1, v.Security.Authorized, "Redacted credentials not counted")
+}
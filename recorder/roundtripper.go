@@ -0,0 +1,78 @@
+package recorder
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+//RoundTripper records each request made through it, and the response it received,
+//in the Recorder. Requests are passed on to Next, or http.DefaultTransport if
+//Next is nil. Requests that fail without a response are not recorded
+type RoundTripper struct {
+	Recorder *Recorder
+	Next     http.RoundTripper
+}
+
+//NewRoundTripper returns a RoundTripper that records the requests made through
+//the next RoundTripper in the passed Recorder. It can be installed in the
+//Transport of an http.Client
+func NewRoundTripper(r *Recorder, next http.RoundTripper) *RoundTripper {
+	return &RoundTripper{
+		Recorder: r,
+		Next:     next,
+	}
+}
+
+//RoundTrip passes the request on to the next RoundTripper and records it. The
+//request body is recorded when it can be read again using the request's GetBody,
+//and the response body is recorded when it is JSON
+func (rt *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := rt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	var body []byte
+	if req.Body != nil && req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err == nil {
+			body, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	var respBody []byte
+	if IsJSON(resp.Header) && resp.Body != nil {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		//Return the error reading the body to the caller when it reads the body
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(respBody), errorReader{err}))
+		if err != nil {
+			respBody = nil
+		}
+	}
+	tle, err := NewEntry(req, body, resp.StatusCode, resp.Header, respBody, start, time.Now(), rt.Recorder.Normalisation)
+	//Skip requests to URLs without a path, which can't be routed to a service
+	if err == nil {
+		rt.Recorder.Record(tle)
+	}
+	return resp, nil
+}
+
+//errorReader returns its error from every read, or io.EOF if the error is nil
+type errorReader struct {
+	err error
+}
+
+//Read returns the reader's error
+func (er errorReader) Read(p []byte) (int, error) {
+	if er.err == nil {
+		return 0, io.EOF
+	}
+	return 0, er.err
+}
@@ -0,0 +1,117 @@
+package recorder
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codeafix/apicovchk/coverage"
+	. "github.com/codeafix/apicovchk/internal/testutil"
+)
+
+func NewTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Query().Get("status") == "":
+			w.WriteHeader(http.StatusBadRequest)
+		case req.Method == "GET":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `[{"name":"rex"}]`)
+		default:
+			req.ParseForm()
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+}
+
+func TestRoundTripperRecordsRequests(t *testing.T) {
+	srv := NewTestServer()
+	defer srv.Close()
+	r := NewRecorder()
+	client := &http.Client{Transport: NewRoundTripper(r, nil)}
+
+	resp, err := client.Get(srv.URL + "/petstore/pet/findByStatus?status=sold")
+	AssertSuccess(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	AssertSuccess(t, err)
+	AreEqual(t, `[{"name":"rex"}]`, string(body), "Response body not passed on")
+
+	resp, err = client.PostForm(srv.URL+"/petstore/pet", url.Values{"name": {"rex"}})
+	AssertSuccess(t, err)
+	resp.Body.Close()
+
+	entries := r.Entries()
+	AreEqual(t, 2, len(entries), "Wrong number of entries recorded")
+	AreEqual(t, "GET", entries[0].Method, "Method not recorded")
+	AreEqual(t, "/pet/findByStatus", entries[0].Path, "Path not recorded")
+	AreEqual(t, "sold", entries[0].Query.Get("status"), "Query not recorded")
+	AreEqual(t, "200", entries[0].Response, "Response not recorded")
+	_, ok := entries[0].ResponseBody.([]interface{})
+	IsTrue(t, ok, "JSON response body not recorded")
+	AreEqual(t, "POST", entries[1].Method, "Method not recorded")
+	AreEqual(t, "201", entries[1].Response, "Response not recorded")
+	AreEqual(t, "rex", entries[1].Form.Get("name"), "Form body not recorded")
+	AreEqual(t, "application/x-www-form-urlencoded", entries[1].Headers.Get("Content-Type"), "Headers not recorded")
+}
+
+func TestRoundTripperRecordsRequestWithoutMethodAsGet(t *testing.T) {
+	srv := NewTestServer()
+	defer srv.Close()
+	r := NewRecorder()
+	client := &http.Client{Transport: NewRoundTripper(r, nil)}
+	u, err := url.Parse(srv.URL + "/petstore/pet/findByStatus?status=sold")
+	AssertSuccess(t, err)
+	resp, err := client.Do(&http.Request{URL: u})
+	AssertSuccess(t, err)
+	resp.Body.Close()
+
+	entries := r.Entries()
+	AreEqual(t, 1, len(entries), "Wrong number of entries recorded")
+	AreEqual(t, "GET", entries[0].Method, "Empty method not recorded as GET")
+	AreEqual(t, "200", entries[0].Response, "Response not recorded")
+}
+
+func TestRoundTripperDoesNotRecordFailedRequests(t *testing.T) {
+	srv := NewTestServer()
+	srv.Close()
+	r := NewRecorder()
+	client := &http.Client{Transport: NewRoundTripper(r, nil)}
+	_, err := client.Get(srv.URL + "/petstore/pet/findByStatus")
+	IsTrue(t, err != nil, "Request to a closed server did not fail")
+	AreEqual(t, 0, len(r.Entries()), "Failed request recorded")
+}
+
+func TestRoundTripperFeedsCoverage(t *testing.T) {
+	srv := NewTestServer()
+	defer srv.Close()
+	r := NewRecorder()
+	client := &http.Client{Transport: NewRoundTripper(r, http.DefaultTransport)}
+	wg := sync.WaitGroup{}
+	for _, status := range []string{"", "sold", "available"} {
+		wg.Add(1)
+		go func(status string) {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL + "/petstore/pet/findByStatus?status=" + status)
+			AssertSuccess(t, err)
+			resp.Body.Close()
+		}(status)
+	}
+	wg.Wait()
+	resp, err := client.Post(srv.URL+"/petstore/pet", "application/x-www-form-urlencoded", strings.NewReader("name=rex"))
+	AssertSuccess(t, err)
+	resp.Body.Close()
+
+	cc := coverage.NewCovChecker()
+	cc.PathMap = NewTestPathMap(t)
+	cc, err = cc.AddEntries(r.RequestLogEntries()...).Result()
+	AssertSuccess(t, err)
+	AreEqual(t, 2, cc.Operations, "Wrong number of operations")
+	AreEqual(t, 2, cc.OperationsCovered, "Wrong number of operations called")
+	AreEqual(t, 1.0, cc.Coverage, "Recorded requests did not cover the API")
+}
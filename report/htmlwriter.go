@@ -0,0 +1,841 @@
+//Package report writes the coverage stats calculated by the coverage package as
+//an HTML report
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/codeafix/apicovchk/coverage"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+//CHECK is the unicode charater for a tick (check) mark
+const CHECK = "&#x2713"
+
+//HTMLWriter contains a reference to the Coverage Check that needs to be written out as an HTML file
+type HTMLWriter struct {
+	CovCheckerInfo *coverage.CovCheckerInfo
+	Buffer         *bytes.Buffer
+}
+
+//NewHTMLWriter returns a new instance of the HTMLWriter
+func NewHTMLWriter(covChecker *coverage.CovCheckerInfo) *HTMLWriter {
+	return &HTMLWriter{
+		CovCheckerInfo: covChecker,
+		Buffer:         new(bytes.Buffer),
+	}
+}
+
+//Write the HTML into the buffer and then into the named file
+func (hw *HTMLWriter) Write(outfilename string) error {
+	hw.Generate()
+
+	f, err := os.Create(outfilename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s := hw.Buffer.String()
+	_, err = f.Write([]byte(s))
+	return err
+}
+
+//Generate writes the HTML report into the buffer
+func (hw *HTMLWriter) Generate() {
+	hw.Buffer.Reset()
+	hw.AddStaticContent()
+	hw.PrintCriticalGaps()
+	hw.AddTableHeader()
+	hw.PrintTotalRow()
+	hw.IterateServices()
+	hw.AddTrailingContent()
+	hw.PrintTagCoverage()
+	hw.PrintOwnerCoverage()
+	hw.PrintPolicyViolations()
+	hw.PrintContractViolations()
+	hw.PrintDeprecatedCalls()
+	hw.PrintSpecQuality()
+	hw.AddClosingContent()
+}
+
+//AddStaticContent adds all the head, style, and script tags into the HTML
+func (hw *HTMLWriter) AddStaticContent() {
+	fmt.Fprintf(hw.Buffer, `
+<!DOCTYPE html>
+<html>
+<head>
+	<script src="https://ajax.googleapis.com/ajax/libs/jquery/3.4.1/jquery.min.js"></script>
+</head>
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<style>
+
+p, h1, h2, td, th, span{
+	font-family: Calibri;
+}
+
+th, td {
+	border-bottom: 1px solid lightgrey;
+	padding-right: 6px;
+	font-size: 16px;
+	vertical-align: bottom;
+	border-collapse: collapse;
+}
+
+th {
+	text-align: left;
+	padding-left: 6px;
+}
+
+table {
+	border-top: 1px solid lightgrey;
+	border-left: 1px solid lightgrey;
+	border-right: 1px solid lightgrey;
+	border-collapse: separate;
+	border-spacing: 0;
+}
+
+.tableHeader {
+	padding: 2px 0;
+	position: sticky;
+	top: 0;
+	background: white;
+	z-index: 10;
+	padding-left: 6px;
+	padding-right: 6px;
+}
+
+.covCol {
+	padding-left: 6px;
+}
+
+.docCol {
+	padding-left: 6px;
+}
+
+.verbDetail {
+	font-style: italic;
+}
+.verbCounts {
+	font-style: italic;
+	text-align: center;
+}
+
+.check {
+	color: green;
+	text-align: center;
+}
+
+.caret {
+	cursor: pointer;
+	-webkit-user-select: none; /* Safari 3.1+ */
+	-moz-user-select: none; /* Firefox 2+ */
+	-ms-user-select: none; /* IE 10+ */
+	user-select: none;
+}
+
+.expand .caret::before {
+	content: "\23F5";
+	color: grey;
+	display: inline-block;
+	margin-right: 2px;
+}
+
+.collapse .caret::before {
+	content: "\23F7";
+	color: grey;
+	display: inline-block;
+	margin-right: 2px;  
+}
+
+.level1 td:first-child {
+	padding-left: 19px;
+}
+.level2 td:first-child {
+	padding-left: 37px;
+}
+.level3 td:first-child {
+	padding-left: 55px;
+}
+.level1{
+	display: none;
+}
+.level2 {
+	display: none;
+}
+.level3 {
+	display: none;
+}
+meter { opacity: 0.6; }
+.meter-value {
+	display: block; height: 0px;
+	position: relative;
+	text-align: center;
+	top: -19px;
+}
+</style>
+<body>
+<script>
+	$(function() {
+		$('#covTable, #tagTable').on('click', '.caret', function () {
+			//Gets all <tr>'s  of greater depth
+			//below element in the table
+			var findChildren = function (tr) {
+				var depth = tr.data('depth');
+				return tr.nextUntil($('tr').filter(function () {
+					return $(this).data('depth') <= depth;
+				}));
+			};
+	
+			var el = $(this);
+			var tr = el.closest('tr'); //Get <tr> parent of toggle button
+			var children = findChildren(tr);
+	
+			//Remove already collapsed nodes from children so that we don't
+			//make them visible. 
+			//(Confused? Remove this code and close Item 2, close Item 1 
+			//then open Item 1 again, then you will understand)
+			var subnodes = children.filter('.expand');
+			subnodes.each(function () {
+				var subnode = $(this);
+				var subnodeChildren = findChildren(subnode);
+				children = children.not(subnodeChildren);
+			});
+	
+			//Change icon and hide/show children
+			if (tr.hasClass('collapse')) {
+				tr.removeClass('collapse').addClass('expand');
+				children.hide();
+			} else {
+				tr.removeClass('expand').addClass('collapse');
+				children.show();
+			}
+			return children;
+		});
+	});
+</script>
+`)
+}
+
+//AddTableHeader adds the opening and header tags of the coverage table
+func (hw *HTMLWriter) AddTableHeader() {
+	fmt.Fprintf(hw.Buffer, `
+<table id="covTable">
+	<thead>
+		<tr>
+			<th class="tableHeader">Path</th>
+			<th class="tableHeader covCol">Coverage</th>
+			<th class="tableHeader docCol">Documented</th>
+		</tr>
+	</thead>
+	<tbody>	
+`)
+}
+
+//PrintCriticalGaps adds a table, ahead of the coverage table, of the operations
+//with a higher than default criticality that are not fully covered. The most
+//critical and least covered operations are listed first
+func (hw *HTMLWriter) PrintCriticalGaps() {
+	gaps := hw.CovCheckerInfo.CriticalGaps
+	if len(gaps) == 0 {
+		return
+	}
+	fmt.Fprintf(hw.Buffer, `
+<h2>Critical operations not fully covered (%d)</h2>
+<table>
+	<thead>
+		<tr>
+			<th>Criticality</th>
+			<th>Service</th>
+			<th>Path</th>
+			<th>Verb</th>
+			<th class="covCol">Coverage</th>
+		</tr>
+	</thead>
+	<tbody>
+`, len(gaps))
+	for _, gap := range gaps {
+		fmt.Fprintf(hw.Buffer, `
+    <tr>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+    </tr>
+`, gap.Criticality, gap.Service, gap.Path, VerbLabel(gap.Method, coverage.VerbStat{OperationID: gap.OperationID}), gap.Coverage, gap.Coverage*100)
+	}
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//PrintTotalRow adds the total stats into the table
+func (hw *HTMLWriter) PrintTotalRow() {
+	cc := hw.CovCheckerInfo
+	fmt.Fprintf(hw.Buffer, `
+	<tr data-depth="0">
+		<th >Total</th>
+		<td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+		<td class="docCol"><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+	</tr>
+`, cc.Coverage, cc.Coverage*100, 1-cc.Undocumented, (1-cc.Undocumented)*100)
+	hw.PrintOperationsRow("Operations called", cc.OperationsCovered, cc.Operations)
+	if len(cc.Weights) > 0 {
+		hw.PrintWeightedRow("Weighted total", cc.Weighted)
+	}
+	if cc.HasCriticality {
+		hw.PrintWeightedRow("Risk-weighted total", cc.Risk)
+	}
+}
+
+//PrintOperationsRow adds a row with the number of documented operations that have
+//been called out of the total number of documented operations into the table
+func (hw *HTMLWriter) PrintOperationsRow(name string, covered, total int) {
+	ratio := coverage.Ratio(float64(covered), float64(total))
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="0">
+        <th class="verbDetail">%s</th>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%d/%d</span></td>
+        <td class="docCol"></td>
+    </tr>
+`, name, ratio, covered, total)
+}
+
+//PrintWeightedRow adds a row with a weighted coverage score into the table
+func (hw *HTMLWriter) PrintWeightedRow(name string, weighted float64) {
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="0">
+        <th class="verbDetail">%s</th>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td class="docCol"></td>
+    </tr>
+`, name, weighted, weighted*100)
+}
+
+//IterateServices iterates over all the services printing content rows for each endpoint
+func (hw *HTMLWriter) IterateServices() {
+	for _, ss := range hw.CovCheckerInfo.ServiceStats {
+		hw.PrintServiceCoverage(ss)
+		hw.PrintOperationsRow(fmt.Sprintf("%s operations called", ss.Name), ss.OperationsCovered, ss.Operations)
+		if len(hw.CovCheckerInfo.Weights) > 0 {
+			hw.PrintWeightedRow(fmt.Sprintf("%s weighted", ss.Name), ss.Weighted)
+		}
+		if hw.CovCheckerInfo.HasCriticality {
+			hw.PrintWeightedRow(fmt.Sprintf("%s risk-weighted", ss.Name), ss.Risk)
+		}
+		for _, ep := range ss.Endpoints {
+			hw.PrintEndpointCoverage(ep)
+			for _, verb := range ep.Verbs {
+				hw.PrintVerbCoverage(verb)
+				hw.PrintCalledRow(verb.Called)
+				hw.PrintResponsesHeader(verb.Responses)
+				for _, resp := range pathmap.SortedResponses(verb.Responses) {
+					c := resp.Covered > 0
+					hw.PrintDetailRow(resp.DisplayName(), c, resp.Documented)
+				}
+				hw.PrintResponseClassesRow(verb.Classes)
+				hw.PrintQueriesHeader(verb.Parameters)
+				for _, param := range pathmap.SortedParameters(verb.Parameters) {
+					c := param.Covered > 0
+					hw.PrintDetailRow(param.Key, c, param.Documented)
+				}
+				if len(verb.FormParams) > 0 {
+					hw.PrintFormParamsHeader(verb.FormParams)
+					for _, param := range pathmap.SortedParameters(verb.FormParams) {
+						c := param.Covered > 0
+						hw.PrintDetailRow(param.Key, c, param.Documented)
+					}
+				}
+				if verb.Security != nil {
+					hw.PrintSecurityHeader(verb.Security)
+					hw.PrintDetailRow("authorized", verb.Security.Authorized > 0, true)
+					hw.PrintDetailRow("unauthorized (401/403)", verb.Security.Unauthorized > 0, true)
+					if verb.Security.Unprotected > 0 {
+						hw.PrintDetailRow("accepted without credentials", true, false)
+					}
+				}
+			}
+		}
+	}
+}
+
+//PrintServiceCoverage adds the total stats for a specific service into the table
+func (hw *HTMLWriter) PrintServiceCoverage(ss *coverage.ServiceStat) {
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="0">
+        <th>%s</th>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td class="docCol"><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+    </tr>
+`, ss.Name, ss.Coverage, ss.Coverage*100, 1-ss.Undocumented, (1-ss.Undocumented)*100)
+}
+
+//PrintEndpointCoverage adds the stats for a endpoint in a service into the table
+func (hw *HTMLWriter) PrintEndpointCoverage(ep coverage.EndpointStat) {
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="0" class="expand level0">
+        <td><span class="caret expand"></span>%s</td>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td class="docCol"><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+    </tr>
+`, ep.Path, ep.Coverage, ep.Coverage*100, 1-ep.Undocumented, (1-ep.Undocumented)*100)
+}
+
+//PrintVerbCoverage adds the stats for a endpoint in a service into the table
+func (hw *HTMLWriter) PrintVerbCoverage(verb coverage.VerbStat) {
+	coverage := verb.CoverageRatio()
+	undocumented := verb.UndocumentedRatio()
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="1" class="expand level1">
+        <td><span class="caret expand"></span>%s</td>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td class="docCol"><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+    </tr>
+`, VerbLabel(verb.Method, verb), coverage, coverage*100, 1-undocumented, (1-undocumented)*100)
+}
+
+//VerbLabel returns the name to show for a verb in the table, with the verb's
+//operationId beside it when it has one
+func VerbLabel(name string, verb coverage.VerbStat) string {
+	if verb.OperationID == "" {
+		return name
+	}
+	return fmt.Sprintf(`%s <span class="verbDetail" title="%s">(%s)</span>`, name, html.EscapeString(verb.Summary), verb.OperationID)
+}
+
+//PrintTagCoverage adds a table of the coverage grouped by the Swagger tags of the
+//operations rather than by path, with the operations of each tag below it
+func (hw *HTMLWriter) PrintTagCoverage() {
+	tags := hw.CovCheckerInfo.TagStats
+	if len(tags) == 0 || (len(tags) == 1 && tags[0].Name == coverage.UntaggedTag) {
+		return
+	}
+	fmt.Fprintf(hw.Buffer, `
+<h2>Coverage by tag</h2>
+<table id="tagTable">
+	<thead>
+		<tr>
+			<th class="tableHeader">Tag</th>
+			<th class="tableHeader covCol">Coverage</th>
+			<th class="tableHeader docCol">Documented</th>
+		</tr>
+	</thead>
+	<tbody>
+`)
+	for _, ts := range tags {
+		fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="0" class="expand level0">
+        <td><span class="caret expand"></span>%s</td>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td class="docCol"><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+    </tr>
+`, ts.Name, ts.Coverage, ts.Coverage*100, 1-ts.Undocumented, (1-ts.Undocumented)*100)
+		for _, verb := range ts.Verbs {
+			coverage := verb.CoverageRatio()
+			undocumented := verb.UndocumentedRatio()
+			name := fmt.Sprintf("%s %s %s", verb.Service, verb.Method, verb.Path)
+			fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="1" class="expand level1">
+        <td>%s</td>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td class="docCol"><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+    </tr>
+`, VerbLabel(name, verb), coverage, coverage*100, 1-undocumented, (1-undocumented)*100)
+		}
+	}
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//PrintCalledRow prints the row for the verb's point for having been called
+func (hw *HTMLWriter) PrintCalledRow(called bool) {
+	cs := "\"></td>"
+	if called {
+		cs = fmt.Sprintf(" check\">%s</td>", CHECK)
+	}
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="2" class="expand level2">
+        <td class="verbDetail">Called</td>
+        <td class="covCol%s
+        <td class="docCol"></td>
+    </tr>
+`, cs)
+}
+
+//PrintResponsesHeader prints the row for the responses into the table
+func (hw *HTMLWriter) PrintResponsesHeader(r map[string]*pathmap.Response) {
+	c := 0
+	d := 0
+	for _, resp := range r {
+		if resp.Covered > 0 {
+			c++
+		}
+		if resp.Documented {
+			d++
+		}
+	}
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="2" class="expand level2">
+        <td class="verbDetail"><span class="caret expand"></span>Responses</td>
+        <td class="verbCounts">%d/%d</td>
+        <td class="verbCounts">%d/%d</td>
+    </tr>
+`, c, len(r), d, len(r))
+}
+
+//PrintResponseClassesRow prints the covered responses in each class of response
+//code into the table so that happy path only testing is easy to spot
+func (hw *HTMLWriter) PrintResponseClassesRow(classes map[string]*coverage.ClassStat) {
+	counts := []string{}
+	for _, class := range []string{pathmap.SuccessClass, pathmap.ClientErrorClass, pathmap.ServerErrorClass} {
+		cs := classes[class]
+		counts = append(counts, fmt.Sprintf("%s %d/%d", class, cs.Covered, cs.Total))
+	}
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="2" class="expand level2">
+        <td class="verbDetail">Response classes</td>
+        <td class="verbCounts" colspan="2">%s</td>
+    </tr>
+`, strings.Join(counts, ", "))
+}
+
+//PrintQueriesHeader the row for the responses into the table
+func (hw *HTMLWriter) PrintQueriesHeader(p map[string]*pathmap.QueryParameter) {
+	c := 0
+	d := 0
+	for _, resp := range p {
+		if resp.Covered > 0 {
+			c++
+		}
+		if resp.Documented {
+			d++
+		}
+	}
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="2" class="expand level2">
+        <td class="verbDetail"><span class="caret expand"></span>Parameters</td>
+        <td class="verbCounts">%d/%d</td>
+        <td class="verbCounts">%d/%d</td>
+    </tr>
+`, c, len(p), d, len(p))
+}
+
+//PrintFormParamsHeader prints the row for the formData parameters into the table
+func (hw *HTMLWriter) PrintFormParamsHeader(p map[string]*pathmap.QueryParameter) {
+	c := 0
+	d := 0
+	for _, param := range p {
+		if param.Covered > 0 {
+			c++
+		}
+		if param.Documented {
+			d++
+		}
+	}
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="2" class="expand level2">
+        <td class="verbDetail"><span class="caret expand"></span>Form Parameters</td>
+        <td class="verbCounts">%d/%d</td>
+        <td class="verbCounts">%d/%d</td>
+    </tr>
+`, c, len(p), d, len(p))
+}
+
+//PrintSecurityHeader prints the row for the security requirements into the table
+func (hw *HTMLWriter) PrintSecurityHeader(sec *pathmap.Security) {
+	c := 0
+	if sec.Authorized > 0 {
+		c++
+	}
+	if sec.Unauthorized > 0 {
+		c++
+	}
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="2" class="expand level2">
+        <td class="verbDetail"><span class="caret expand"></span>Security (%s)</td>
+        <td class="verbCounts">%d/2</td>
+        <td class="verbCounts">2/2</td>
+    </tr>
+`, strings.Join(sec.Schemes, ", "), c)
+}
+
+//PrintDetailRow prints a detail row for a response or query into the table
+func (hw *HTMLWriter) PrintDetailRow(name string, covered bool, documented bool) {
+	checkString := fmt.Sprintf(" check\">%s</td>", CHECK)
+	noCheckString := "\"></td>"
+	cs := noCheckString
+	if covered {
+		cs = checkString
+	}
+	ds := noCheckString
+	if documented {
+		ds = checkString
+	}
+	fmt.Fprintf(hw.Buffer, `
+    <tr data-depth="3" class="expand level3">
+        <td>%s</td>
+        <td class="covCol%s
+        <td class="docCol%s
+    </tr>
+`, name, cs, ds)
+}
+
+//AddTrailingContent adds the html close tags for the coverage table
+func (hw *HTMLWriter) AddTrailingContent() {
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//PrintOwnerCoverage adds a table of the coverage of the operations owned by each
+//team, marking the teams whose coverage is below their threshold
+func (hw *HTMLWriter) PrintOwnerCoverage() {
+	owners := hw.CovCheckerInfo.OwnerStats
+	if len(owners) == 0 || (len(owners) == 1 && owners[0].Name == coverage.UnownedOwner) {
+		return
+	}
+	fmt.Fprintf(hw.Buffer, `
+<h2>Coverage by owner</h2>
+<table>
+	<thead>
+		<tr>
+			<th>Owner</th>
+			<th>Operations</th>
+			<th class="covCol">Coverage</th>
+			<th class="docCol">Documented</th>
+			<th>Threshold</th>
+		</tr>
+	</thead>
+	<tbody>
+`)
+	for _, owner := range owners {
+		threshold := fmt.Sprintf("%3.2f%%", owner.Threshold*100)
+		if owner.BelowThreshold() {
+			threshold = fmt.Sprintf(`<span style="color: red">%s below threshold</span>`, threshold)
+		}
+		fmt.Fprintf(hw.Buffer, `
+    <tr>
+        <td>%s</td>
+        <td class="verbCounts">%d</td>
+        <td class="covCol"><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td class="docCol"><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter><span class="meter-value">%3.2f%%</span></td>
+        <td>%s</td>
+    </tr>
+`, owner.Name, owner.Operations, owner.Coverage, owner.Coverage*100, 1-owner.Undocumented, (1-owner.Undocumented)*100, threshold)
+	}
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//PrintPolicyViolations adds a table listing the operations that do not satisfy
+//the coverage policy
+func (hw *HTMLWriter) PrintPolicyViolations() {
+	violations := hw.CovCheckerInfo.Violations
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintf(hw.Buffer, `
+<h2>Policy violations (%d)</h2>
+<table>
+	<thead>
+		<tr>
+			<th>Service</th>
+			<th>Path</th>
+			<th>Verb</th>
+			<th>Violation</th>
+		</tr>
+	</thead>
+	<tbody>
+`, len(violations))
+	for _, pv := range violations {
+		fmt.Fprintf(hw.Buffer, `
+    <tr>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+    </tr>
+`, pv.Service, pv.Path, pv.Method, pv.Message)
+	}
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//PrintContractViolations adds a table listing the ways the logged calls to each
+//endpoint broke the contract in its Swagger description
+func (hw *HTMLWriter) PrintContractViolations() {
+	violations := hw.CovCheckerInfo.ContractViolations
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintf(hw.Buffer, `
+<h2>Contract violations (%d)</h2>
+<table>
+	<thead>
+		<tr>
+			<th>Service</th>
+			<th>Path</th>
+			<th>Verb</th>
+			<th>Violation</th>
+			<th>Calls</th>
+		</tr>
+	</thead>
+	<tbody>
+`, len(violations))
+	for _, cv := range violations {
+		fmt.Fprintf(hw.Buffer, `
+    <tr>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%d</td>
+    </tr>
+`, cv.Service, cv.Path, cv.Method, html.EscapeString(cv.Message), cv.Count)
+	}
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//PrintDeprecatedCalls adds a table warning of the deprecated operations that are
+//still being called in the logs
+func (hw *HTMLWriter) PrintDeprecatedCalls() {
+	calls := hw.CovCheckerInfo.DeprecatedCalls
+	if len(calls) == 0 {
+		return
+	}
+	fmt.Fprintf(hw.Buffer, `
+<h2>Deprecated operations still in use (%d)</h2>
+<table>
+	<thead>
+		<tr>
+			<th>Service</th>
+			<th>Path</th>
+			<th>Verb</th>
+			<th>Calls</th>
+		</tr>
+	</thead>
+	<tbody>
+`, len(calls))
+	for _, dc := range calls {
+		fmt.Fprintf(hw.Buffer, `
+    <tr>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%d</td>
+    </tr>
+`, dc.Service, dc.Path, dc.Method, dc.Calls)
+	}
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//PrintSpecQuality adds a table listing the quality findings for the Swagger files
+//so that gaps in the documentation are visible even without any logs
+func (hw *HTMLWriter) PrintSpecQuality() {
+	findings := hw.CovCheckerInfo.PathMap.Findings
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintf(hw.Buffer, `
+<h2>Spec quality (%d)</h2>
+<table>
+	<thead>
+		<tr>
+			<th>Service</th>
+			<th>Path</th>
+			<th>Verb</th>
+			<th>Finding</th>
+		</tr>
+	</thead>
+	<tbody>
+`, len(findings))
+	for _, lf := range findings {
+		fmt.Fprintf(hw.Buffer, `
+    <tr>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+        <td>%s</td>
+    </tr>
+`, lf.Service, lf.Path, lf.Method, html.EscapeString(lf.Message))
+	}
+	fmt.Fprintf(hw.Buffer, `
+</tbody>
+</table>
+`)
+}
+
+//AddClosingContent adds the html close tags at the end of the file
+func (hw *HTMLWriter) AddClosingContent() {
+	fmt.Fprintf(hw.Buffer, `
+</body>
+</html>
+`)
+}
+
+//PrintStats prints the calculated coverage stats of the Coverage Checker into an
+//HTML document
+func PrintStats(cc *coverage.CovCheckerInfo) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<html>
+<h1>Summary</h1>
+<table>
+<tr><td>Overall API coverage</td><td><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter> %3.2f%%</td></tr>
+<tr><td>Overall API documentation</td><td><meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter> %3.2f%%</td></tr>
+</table>
+<h2>Coverage summary by Service</h2>
+<table>
+`, cc.Coverage, cc.Coverage*100, 1-cc.Undocumented, (1-cc.Undocumented)*100)
+
+	//First let's print the summary of services
+	for _, ss := range cc.ServiceStats {
+		fmt.Fprintf(&buf,
+			`<tr>
+  <td>%s</td>
+  <td><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter> Coverage: %3.2f%%<br/>
+  <meter min="0"  max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter> Documented: %3.2f%%
+  </td>
+</tr>
+`, ss.Name, ss.Coverage, ss.Coverage*100, 1-ss.Undocumented, (1-ss.Undocumented)*100)
+	}
+	fmt.Fprintln(&buf, `</table>`)
+
+	for _, ss := range cc.ServiceStats {
+		fmt.Fprintf(&buf, `<h1>%s</h1>
+<table>
+`, ss.Name)
+
+		for _, ep := range ss.Endpoints {
+			fmt.Fprintf(&buf,
+				`<tr>
+  <td>%s</td>
+  <td><meter min="0" max="1" low="0.8" high="0.8" optimum="1" value="%3.2f"></meter> Coverage: %3.2f%%<br/>
+  <meter min="0" max="1" low=".9999" high=".9999" optimum="1" value="%3.2f"></meter> Documented: %3.2f%%
+  </td>
+</tr>
+`, ep.Path, ep.Coverage, ep.Coverage*100, 1-ep.Undocumented, (1-ep.Undocumented)*100)
+		}
+
+		fmt.Fprintln(&buf, `</table>`)
+	}
+	fmt.Fprintln(&buf, `</html>`)
+	return buf.String()
+}
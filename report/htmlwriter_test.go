@@ -0,0 +1,81 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/codeafix/apicovchk/coverage"
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/codeafix/apicovchk/pathmap"
+)
+
+func TestWriteOutput(t *testing.T) {
+	conf := coverage.Config{}
+	PetstoreConfig(t, &conf)
+	cc, err := coverage.New(conf).AddLogs().Result()
+	AssertSuccess(t, err)
+	hw := NewHTMLWriter(cc)
+	err = hw.Write(TempPath("out.html"))
+	AssertSuccess(t, err)
+}
+
+func TestWriteWithoutPointsHasNoNaN(t *testing.T) {
+	c := `{"swagger":"2.0","paths":{
+		"/pet":{"get":{"responses":{}}},
+		"/store":{"get":{"x-coverage-ignore":true,"responses":{"200":{"description":"ok"}}}}
+	}}`
+	cc := coverage.NewCovChecker()
+	cc.Weights = coverage.Weights{coverage.SuccessResponseWeight: 0}
+	cc.Ownership = coverage.Ownership{Rules: []coverage.OwnerRule{{Path: "/**", Owner: "team-pets"}}}
+	MapSwagger(t, cc.PathMap, "petstore", c)
+	MapSwagger(t, cc.PathMap, "empty", `{"swagger":"2.0","paths":{}}`)
+	cc.NavigatePathMap()
+	for _, v := range []float64{cc.Coverage, cc.Undocumented, cc.Weighted, cc.Risk} {
+		IsFalse(t, math.IsNaN(v), "Overall statistic is NaN")
+	}
+	for _, ss := range cc.ServiceStats {
+		for _, v := range []float64{ss.Coverage, ss.Undocumented, ss.Weighted, ss.Risk} {
+			IsFalse(t, math.IsNaN(v), fmt.Sprintf("Statistic for service '%s' is NaN", ss.Name))
+		}
+		for _, es := range ss.Endpoints {
+			IsFalse(t, math.IsNaN(es.Coverage) || math.IsNaN(es.Undocumented) || math.IsNaN(es.Weighted), "Endpoint statistic is NaN")
+		}
+	}
+	for _, ts := range cc.TagStats {
+		IsFalse(t, math.IsNaN(ts.Coverage) || math.IsNaN(ts.Undocumented), "Tag statistic is NaN")
+	}
+	for _, owner := range cc.OwnerStats {
+		IsFalse(t, math.IsNaN(owner.Coverage) || math.IsNaN(owner.Undocumented), "Owner statistic is NaN")
+	}
+	hw := NewHTMLWriter(cc)
+	err := hw.Write(TempPath("nonan.html"))
+	AssertSuccess(t, err)
+	IsFalse(t, strings.Contains(hw.Buffer.String(), "NaN"), "Report contains NaN")
+}
+
+func TestReportIsDeterministic(t *testing.T) {
+	c := coverage.Config{}
+	PetstoreConfig(t, &c)
+	c.Services = append(c.Services, pathmap.ServiceEntry{Name: "another", RoutePath: "another", Swagger: c.Services[0].Swagger})
+	reports := []string{}
+	for i := 0; i < 5; i++ {
+		cc, err := coverage.New(c).AddLogs().Result()
+		AssertSuccess(t, err)
+		AreEqual(t, "another", cc.ServiceStats[0].Name, "Services not sorted")
+		paths := []string{}
+		for _, es := range cc.ServiceStats[1].Endpoints {
+			paths = append(paths, es.Path)
+		}
+		AreEqual(t, "/pet", paths[0], "Endpoints not sorted")
+		AreEqual(t, "/pet/findByStatus", paths[1], "Endpoints not sorted")
+		hw := NewHTMLWriter(cc)
+		err = hw.Write(TempPath("deterministic.html"))
+		AssertSuccess(t, err)
+		reports = append(reports, hw.Buffer.String())
+	}
+	for _, r := range reports[1:] {
+		IsTrue(t, r == reports[0], "Report differs between runs")
+	}
+}
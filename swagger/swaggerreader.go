@@ -0,0 +1,142 @@
+//Package swagger loads Swagger 2.0 API descriptions from local files or web URLs
+package swagger
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/codeafix/apicovchk/filereader"
+	"github.com/go-openapi/spec"
+)
+
+//ResponseRangesExtension is the vendor extension on an operation's responses that
+//lists the range response codes (e.g. "4XX") that are documented for the operation.
+//Swagger 2.0 only supports explicit codes and "default", so range codes found in
+//the responses are moved into this extension when the Swagger is read
+const ResponseRangesExtension = "x-response-ranges"
+
+var rangeCodeRegexp = regexp.MustCompile(`^[1-5][xX][xX]$`)
+
+//SwaggerReaderInfo contains the URL the SwaggerReader should read from
+type SwaggerReaderInfo struct {
+	URLReader filereader.URLReader
+}
+
+//SwaggerReader is used to read data from a URL into a github.com/go-openapi/spec.Swagger struct
+type SwaggerReader interface {
+	GetSwaggerContent() (*spec.Swagger, error)
+}
+
+//NewSwaggerReader returns a new instance of swagger reader
+func NewSwaggerReader(urlstring string) (SwaggerReader, error) {
+	ur, err := filereader.NewURLReader(urlstring)
+	if err != nil {
+		return nil, err
+	}
+	return &SwaggerReaderInfo{URLReader: ur}, nil
+}
+
+//GetSwaggerContent reads the Swagger from the SwaggerReader's URL and returns a spec.Swagger
+func (sr *SwaggerReaderInfo) GetSwaggerContent() (*spec.Swagger, error) {
+	swag := &spec.Swagger{}
+	c, err := sr.URLReader.ReadFromURL()
+	if err != nil {
+		return swag, err
+	}
+	err = swag.UnmarshalJSON(c)
+	if err != nil {
+		return swag, err
+	}
+	err = AddResponseRanges(swag, c)
+	return swag, err
+}
+
+//AddResponseRanges finds the range response codes (e.g. "2XX") in the raw Swagger
+//content, which are discarded when the content is unmarshalled into a spec.Swagger,
+//and adds them to the ResponseRangesExtension of each operation's responses
+func AddResponseRanges(swag *spec.Swagger, content []byte) error {
+	raw := struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}{}
+	err := json.Unmarshal(content, &raw)
+	if err != nil {
+		return err
+	}
+	if swag.Paths == nil {
+		return nil
+	}
+	for path, verbs := range raw.Paths {
+		spi, exists := swag.Paths.Paths[path]
+		if !exists {
+			continue
+		}
+		for verb, rawop := range verbs {
+			op := OperationForVerb(&spi, verb)
+			if op == nil || op.Responses == nil {
+				continue
+			}
+			rawresp := struct {
+				Responses map[string]json.RawMessage `json:"responses"`
+			}{}
+			if json.Unmarshal(rawop, &rawresp) != nil {
+				continue
+			}
+			ranges := []interface{}{}
+			for code := range rawresp.Responses {
+				if rangeCodeRegexp.MatchString(code) {
+					ranges = append(ranges, strings.ToUpper(code))
+				}
+			}
+			if len(ranges) > 0 {
+				existing, _ := op.Responses.Extensions.GetStringSlice(ResponseRangesExtension)
+				for _, code := range existing {
+					ranges = append(ranges, code)
+				}
+				op.Responses.AddExtension(ResponseRangesExtension, ranges)
+			}
+		}
+	}
+	return nil
+}
+
+//OperationForVerb returns the operation for the named verb on the path item
+func OperationForVerb(spi *spec.PathItem, verb string) *spec.Operation {
+	switch strings.ToLower(verb) {
+	case "get":
+		return spi.Get
+	case "put":
+		return spi.Put
+	case "post":
+		return spi.Post
+	case "delete":
+		return spi.Delete
+	case "head":
+		return spi.Head
+	case "options":
+		return spi.Options
+	case "patch":
+		return spi.Patch
+	}
+	return nil
+}
+
+//SetOperationForVerb sets the operation of the passed verb on the PathItem
+func SetOperationForVerb(spi *spec.PathItem, verb string, op *spec.Operation) {
+	switch strings.ToLower(verb) {
+	case "get":
+		spi.Get = op
+	case "put":
+		spi.Put = op
+	case "post":
+		spi.Post = op
+	case "delete":
+		spi.Delete = op
+	case "head":
+		spi.Head = op
+	case "options":
+		spi.Options = op
+	case "patch":
+		spi.Patch = op
+	}
+}
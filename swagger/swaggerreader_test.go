@@ -0,0 +1,45 @@
+package swagger
+
+import (
+	"sort"
+	"testing"
+
+	. "github.com/codeafix/apicovchk/internal/testutil"
+	"github.com/go-openapi/spec"
+)
+
+func TestGetSwaggerContent(t *testing.T) {
+	filepath := FileURL("swagger.json")
+	sr, err := NewSwaggerReader(filepath)
+	AssertSuccess(t, err)
+	c, err := sr.GetSwaggerContent()
+	AssertSuccess(t, err)
+	AreEqual(t, "2.0", c.Swagger, "Incorrect Swagger deserialised")
+}
+
+func TestNewSwaggerReaderFailsWithInvalidURL(t *testing.T) {
+	_, err := NewSwaggerReader("!£$%£%^$^&$%^")
+	IsTrue(t, err != nil, "Did not fail creating a new SwaggerReader when passed an invalid URL")
+}
+
+func TestGetSwaggerContentReturnsError(t *testing.T) {
+	filepath := FileURL("DoesntExist.json")
+	sr, err := NewSwaggerReader(filepath)
+	AssertSuccess(t, err)
+	_, err = sr.GetSwaggerContent()
+	IsTrue(t, err != nil, "Error not returned")
+}
+
+func TestAddResponseRanges(t *testing.T) {
+	c := []byte(`{"swagger":"2.0","paths":{"/pets":{"get":{"responses":{"200":{"description":"ok"},"4XX":{"description":"client error"},"5xx":{"description":"server error"}}}}}}`)
+	swag := &spec.Swagger{}
+	err := swag.UnmarshalJSON(c)
+	AssertSuccess(t, err)
+	err = AddResponseRanges(swag, c)
+	AssertSuccess(t, err)
+	ranges, _ := swag.Paths.Paths["/pets"].Get.Responses.Extensions.GetStringSlice(ResponseRangesExtension)
+	sort.Strings(ranges)
+	AreEqual(t, 2, len(ranges), "Wrong number of response ranges")
+	AreEqual(t, "4XX", ranges[0], "Wrong response range")
+	AreEqual(t, "5XX", ranges[1], "Wrong response range")
+}
@@ -4,7 +4,7 @@ set -e
 mkdir -p temp
 echo "" > temp/coverage.txt
 
-go test -coverprofile=temp/profile.out -covermode=atomic $d
+go test -coverprofile=temp/profile.out -covermode=atomic ./...
 if [ -f temp/profile.out ]; then
     cat temp/profile.out >> temp/coverage.txt
     rm temp/profile.out